@@ -0,0 +1,266 @@
+/*
+Package assert provides terminal-aware assertions for mimic. Failure messages embed the rendered screen (and a diff, where
+applicable) so that a failing test explains what was actually displayed, rather than reporting a bare boolean.
+
+These functions follow the conventions of github.com/stretchr/testify/assert: each accepts a TestingT (such as
+*testing.T), reports failures via Errorf, and returns whether the assertion succeeded.
+*/
+package assert
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jimschubert/mimic"
+)
+
+// TestingT is the subset of testing.TB used to report assertion failures
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+type tHelper interface {
+	Helper()
+}
+
+// ExitCoder is satisfied by values reporting a process exit code, such as *os.ProcessState and *exec.ExitError
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// ScreenContains asserts that the emulated terminal's view contains all expected strings.
+// See mimic.Mimic.ContainsString for how the view is evaluated.
+func ScreenContains(t TestingT, m *mimic.Mimic, expected ...string) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if m.ContainsString(expected...) {
+		return true
+	}
+
+	// the missing strings are found in the captured screen, as checking each via ContainsString would consume lines in
+	// strict mode and record each check in the transcript
+	screen := rows(m)
+	view := strings.Join(screen, "\n")
+	missing := make([]string, 0)
+	for _, s := range expected {
+		if !strings.Contains(view, s) {
+			missing = append(missing, s)
+		}
+	}
+	if len(missing) == 0 {
+		// the view contains each string only literally, not per m's matching options (e.g. mimic.WithPlaceholders)
+		missing = expected
+	}
+	quoted := make([]string, 0, len(missing))
+	for _, s := range missing {
+		quoted = append(quoted, fmt.Sprintf("%q", s))
+	}
+
+	return fail(t, fmt.Sprintf("Screen does not contain: %s", strings.Join(quoted, ", ")), render(screen), diff(m, strings.Join(missing, "\n"), strings.Join(trimRows(screen), "\n")))
+}
+
+// ScreenContainsBlock asserts that the emulated terminal's view contains lines on consecutive rows, in order.
+// See mimic.Mimic.ContainsBlock for how lines are compared.
+func ScreenContainsBlock(t TestingT, m *mimic.Mimic, lines ...string) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if m.ContainsBlock(lines...) {
+		return true
+	}
+
+	screen := rows(m)
+	expected := strings.Join(lines, "\n")
+	return fail(t, "Screen does not contain block", render(screen), diff(m, expected, strings.Join(trimRows(screen), "\n")))
+}
+
+// RowEquals asserts that the given zero-based row of the emulated terminal's view equals expected.
+// Trailing whitespace is ignored on the row.
+func RowEquals(t TestingT, m *mimic.Mimic, row int, expected string) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	screen := rows(m)
+	if row < 0 || row >= len(screen) {
+		return fail(t, fmt.Sprintf("Row %d is out of range for a screen of %d rows", row, len(screen)), render(screen))
+	}
+
+	actual := screen[row]
+	if actual == expected {
+		return true
+	}
+
+	return fail(t, fmt.Sprintf("Row %d does not equal expected value", row), diff(m, expected, actual), render(screen))
+}
+
+// TableRow asserts that a table in the emulated terminal's view has a row containing cells, in order. The failure
+// message lists the detected tables. See mimic.Mimic.ContainsTableRow for how tables and rows are matched.
+func TableRow(t TestingT, m *mimic.Mimic, cells ...string) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if m.ContainsTableRow(cells...) {
+		return true
+	}
+
+	var b strings.Builder
+	b.WriteString("Tables:")
+	tables := m.Tables()
+	for i, table := range tables {
+		_, _ = fmt.Fprintf(&b, "\n  table %d:", i)
+		for _, row := range table {
+			_, _ = fmt.Fprintf(&b, "\n    %q", row)
+		}
+	}
+	if len(tables) == 0 {
+		b.WriteString(" (none)")
+	}
+	return fail(t, fmt.Sprintf("No table row contains %q", cells), b.String(), render(rows(m)))
+}
+
+// CursorAt asserts that the emulated terminal's cursor is at the given zero-based row and column
+func CursorAt(t TestingT, m *mimic.Mimic, row, column int) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	screen := rows(m)
+	actualRow, actualColumn := m.Cursor()
+	if actualRow == row && actualColumn == column {
+		return true
+	}
+
+	return fail(t, fmt.Sprintf("Cursor expected at (row %d, column %d), but was at (row %d, column %d)", row, column, actualRow, actualColumn), render(screen))
+}
+
+// ForegroundAt asserts that the cell at the given zero-based row and column is rendered with the expected foreground
+// color, e.g. mimic.RGB(0x87, 0x5f, 0xaf). See mimic.Mimic.ForegroundAt.
+func ForegroundAt(t TestingT, m *mimic.Mimic, row, column int, expected mimic.Color) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	actual, err := m.ForegroundAt(row, column)
+	if err != nil {
+		return fail(t, err.Error(), render(rows(m)))
+	}
+	if actual == expected {
+		return true
+	}
+	return fail(t, fmt.Sprintf("Foreground at (row %d, column %d) expected to be %s, but was %s", row, column, expected, actual), render(rows(m)))
+}
+
+// BackgroundAt asserts that the cell at the given zero-based row and column is rendered with the expected background
+// color. See mimic.Mimic.BackgroundAt.
+func BackgroundAt(t TestingT, m *mimic.Mimic, row, column int, expected mimic.Color) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	actual, err := m.BackgroundAt(row, column)
+	if err != nil {
+		return fail(t, err.Error(), render(rows(m)))
+	}
+	if actual == expected {
+		return true
+	}
+	return fail(t, fmt.Sprintf("Background at (row %d, column %d) expected to be %s, but was %s", row, column, expected, actual), render(rows(m)))
+}
+
+// CursorVisible asserts that the emulated terminal's cursor is visible, or hidden if visible is false. See
+// mimic.Mimic.CursorVisible.
+func CursorVisible(t TestingT, m *mimic.Mimic, visible bool) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if m.CursorVisible() == visible {
+		return true
+	}
+	if visible {
+		return fail(t, "Cursor expected to be visible, but was hidden", render(rows(m)))
+	}
+	return fail(t, "Cursor expected to be hidden, but was visible", render(rows(m)))
+}
+
+// Hyperlink asserts that the emulated terminal's output contains an OSC 8 hyperlink with the expected visible text and
+// target URI. See mimic.Mimic.Hyperlinks.
+func Hyperlink(t TestingT, m *mimic.Mimic, text, uri string) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	links := m.Hyperlinks()
+	for _, link := range links {
+		if link.Text == text && link.URI == uri {
+			return true
+		}
+	}
+
+	var found strings.Builder
+	found.WriteString("Hyperlinks:\n")
+	for _, link := range links {
+		_, _ = fmt.Fprintf(&found, "  %q -> %s (row %d, column %d)\n", link.Text, link.URI, link.Row, link.Column)
+	}
+	if len(links) == 0 {
+		found.WriteString("  (none)\n")
+	}
+	return fail(t, fmt.Sprintf("No hyperlink %q -> %s", text, uri), strings.TrimSuffix(found.String(), "\n"), render(rows(m)))
+}
+
+// ExitCode asserts that a process exited with the expected code
+func ExitCode(t TestingT, process ExitCoder, expected int) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if process == nil {
+		return fail(t, fmt.Sprintf("Expected exit code %d, but process has not exited", expected))
+	}
+
+	if actual := process.ExitCode(); actual != expected {
+		return fail(t, fmt.Sprintf("Expected exit code %d, but was %d", expected, actual))
+	}
+
+	return true
+}
+
+func fail(t TestingT, message string, details ...string) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	t.Errorf("%s", strings.Join(append([]string{message}, details...), "\n\n"))
+	return false
+}
+
+// rows flushes pending output and returns the rows of the formatted view, with trailing whitespace removed from each row
+func rows(m *mimic.Mimic) []string {
+	_ = m.Flush()
+	v := mimic.Viewer{Mimic: m, StripAnsi: true, Trim: true}
+	return v.Lines()
+}
+
+// render formats rows as a numbered screen (see mimic.FormatScreen)
+func render(screen []string) string {
+	return "Screen:\n" + mimic.FormatScreen(screen, 0)
+}
+
+// diff formats a line-level diff of expected and actual, colorized per m's configuration (see mimic.WithDiffColor)
+func diff(m *mimic.Mimic, expected, actual string) string {
+	return "Diff:\n" + m.Diff(expected, actual)
+}
+
+// trimRows omits trailing empty rows of screen
+func trimRows(screen []string) []string {
+	last := len(screen)
+	for last > 0 && screen[last-1] == "" {
+		last--
+	}
+	return screen[:last]
+}
@@ -0,0 +1,152 @@
+package assert
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jimschubert/mimic"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingT struct {
+	messages []string
+}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+}
+
+type exitCode int
+
+func (e exitCode) ExitCode() int {
+	return int(e)
+}
+
+func newMimic(t *testing.T, contents string) *mimic.Mimic {
+	m, err := mimic.NewMimic(mimic.WithSize(5, 20), mimic.WithDiffColor(false))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = m.Close() })
+
+	_, err = m.Tty().WriteString(contents)
+	require.NoError(t, err)
+	return m
+}
+
+func TestScreenContains(t *testing.T) {
+	m := newMimic(t, "Hello\r\nWorld")
+
+	rt := &recordingT{}
+	require.True(t, ScreenContains(rt, m, "Hello", "World"))
+	require.Empty(t, rt.messages)
+
+	require.False(t, ScreenContains(rt, m, "Hello", "Puppies"))
+	require.Len(t, rt.messages, 1)
+	require.Contains(t, rt.messages[0], `Screen does not contain: "Puppies"`)
+	require.Contains(t, rt.messages[0], "  0 | Hello\n  1 | World")
+	require.Contains(t, rt.messages[0], "Diff:\n--- Expected\n+++ Actual\n@@ -1 +1,2 @@\n-Puppies\n+Hello\n+World")
+}
+
+func TestScreenContains_checksOnce(t *testing.T) {
+	m, err := mimic.NewMimic(mimic.WithSize(5, 20), mimic.WithTranscript())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = m.Close() })
+	_, err = m.Tty().WriteString("Hello\r\nWorld")
+	require.NoError(t, err)
+
+	rt := &recordingT{}
+	require.False(t, ScreenContains(rt, m, "Hello", "Puppies", "Kittens"))
+	require.Contains(t, rt.messages[0], `Screen does not contain: "Puppies", "Kittens"`)
+	require.Len(t, m.Transcript(), 1, "missing strings are found in the captured screen rather than checked again")
+}
+
+func TestRowEquals(t *testing.T) {
+	m := newMimic(t, "Hello\r\nWorld")
+
+	rt := &recordingT{}
+	require.True(t, RowEquals(rt, m, 1, "World"))
+	require.Empty(t, rt.messages)
+
+	require.False(t, RowEquals(rt, m, 1, "Word"))
+	require.Len(t, rt.messages, 1)
+	require.Contains(t, rt.messages[0], "-Word\n+World")
+
+	require.False(t, RowEquals(rt, m, 10, "World"))
+	require.Contains(t, rt.messages[1], "Row 10 is out of range")
+}
+
+func TestScreenContainsBlock(t *testing.T) {
+	m := newMimic(t, "Name   Age\r\n  Ann   31\r\n  Bob   27")
+
+	rt := &recordingT{}
+	require.True(t, ScreenContainsBlock(rt, m, "Ann   31", "Bob   27"))
+	require.False(t, ScreenContainsBlock(rt, m, "Bob   27", "Ann   31"))
+	require.Len(t, rt.messages, 1)
+	require.Contains(t, rt.messages[0], "Screen does not contain block")
+}
+
+func TestTableRow(t *testing.T) {
+	m := newMimic(t, "NAME   STATUS\r\nweb    Running")
+
+	rt := &recordingT{}
+	require.True(t, TableRow(rt, m, "web", "Running"))
+	require.False(t, TableRow(rt, m, "db", "Running"))
+	require.Len(t, rt.messages, 1)
+	require.Contains(t, rt.messages[0], `No table row contains ["db" "Running"]`)
+	require.Contains(t, rt.messages[0], `["web" "Running"]`)
+}
+
+func TestCursorAt(t *testing.T) {
+	m := newMimic(t, "Hello\r\nWorld")
+
+	rt := &recordingT{}
+	require.True(t, CursorAt(rt, m, 1, 5))
+	require.False(t, CursorAt(rt, m, 0, 0))
+	require.Len(t, rt.messages, 1)
+	require.Contains(t, rt.messages[0], "was at (row 1, column 5)")
+}
+
+func TestForegroundAt(t *testing.T) {
+	m := newMimic(t, "\x1b[38;2;135;95;175mtheme\x1b[0m \x1b[48;5;236mbg")
+
+	rt := &recordingT{}
+	require.True(t, ForegroundAt(rt, m, 0, 0, mimic.RGB(0x87, 0x5f, 0xaf)))
+	require.False(t, ForegroundAt(rt, m, 0, 0, mimic.Color(97)))
+	require.False(t, ForegroundAt(rt, m, 99, 0, mimic.DefaultColor))
+	require.Len(t, rt.messages, 2)
+	require.Contains(t, rt.messages[0], "expected to be color(97), but was #875faf")
+	require.True(t, BackgroundAt(rt, m, 0, 6, mimic.Color(236)))
+	require.False(t, BackgroundAt(rt, m, 0, 0, mimic.Color(236)))
+	require.Contains(t, rt.messages[2], "expected to be color(236), but was default")
+}
+
+func TestCursorVisible(t *testing.T) {
+	m := newMimic(t, "\x1b[?25lWorking")
+
+	rt := &recordingT{}
+	require.True(t, CursorVisible(rt, m, false))
+	require.False(t, CursorVisible(rt, m, true))
+	require.Len(t, rt.messages, 1)
+	require.Contains(t, rt.messages[0], "Cursor expected to be visible, but was hidden")
+}
+
+func TestHyperlink(t *testing.T) {
+	m := newMimic(t, "See \x1b]8;;https://example.com/docs\x1b\\the docs\x1b]8;;\x1b\\.")
+
+	rt := &recordingT{}
+	require.True(t, Hyperlink(rt, m, "the docs", "https://example.com/docs"))
+	require.False(t, Hyperlink(rt, m, "the docs", "https://example.com"))
+	require.Len(t, rt.messages, 1)
+	require.Contains(t, rt.messages[0], `No hyperlink "the docs" -> https://example.com`)
+	require.Contains(t, rt.messages[0], `"the docs" -> https://example.com/docs (row 0, column 4)`)
+}
+
+func TestExitCode(t *testing.T) {
+	rt := &recordingT{}
+	require.True(t, ExitCode(rt, exitCode(0), 0))
+	require.False(t, ExitCode(rt, exitCode(2), 0))
+	require.False(t, ExitCode(rt, nil, 0))
+	require.Equal(t, []string{
+		"Expected exit code 0, but was 2",
+		"Expected exit code 0, but process has not exited",
+	}, rt.messages)
+}
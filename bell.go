@@ -0,0 +1,83 @@
+package mimic
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// oscNotify prefixes the payload of an OSC 777 desktop notification
+var oscNotify = []byte("777;notify;")
+
+// bells counts bells rung by output. OSC 777 desktop notifications, which terminals such as urxvt present in place of a
+// bell, are counted as bells.
+type bells struct {
+	mu     sync.Mutex
+	parser controlParser
+	rung   int
+	// expected is the number of bells consumed by Mimic.ExpectBell
+	expected int
+}
+
+func (b *bells) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, c := range p {
+		ctl, ok := b.parser.feed(c)
+		if ok && (ctl.kind == '\a' || ctl.is(']', oscNotify)) {
+			b.rung++
+		}
+	}
+	return len(p), nil
+}
+
+func (b *bells) count() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.rung
+}
+
+// consume marks the next unexpected bell as expected, reporting whether there was one
+func (b *bells) consume() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.rung > b.expected {
+		b.expected++
+		return true
+	}
+	return false
+}
+
+// BellCount returns the number of times output has rung the bell (BEL), including OSC 777 notifications. Pending
+// output is flushed first, so a count of zero asserts that a tool never rang the bell.
+func (m *Mimic) BellCount() int {
+	_ = m.Flush()
+	return m.bells.count()
+}
+
+// ExpectBell waits for output to ring the bell, returning an error if it isn't rung before the idle timeout (see
+// WithIdleTimeout). Each call consumes one bell, so a bell which rang before the call satisfies it only if no earlier
+// call to ExpectBell consumed it.
+func (m *Mimic) ExpectBell() error {
+	if m.bells.consume() {
+		return nil
+	}
+	_, _, err := m.expect(context.Background(), &bellMatcher{bells: m.bells})
+	if err != nil {
+		return fmt.Errorf("bell not rung (rung %d times in total): %w", m.bells.count(), err)
+	}
+	return nil
+}
+
+// bellMatcher matches, consuming a bell, once output has rung the bell more times than have been expected
+type bellMatcher struct {
+	bells *bells
+}
+
+func (b *bellMatcher) Match(_ interface{}) bool {
+	return b.bells.consume()
+}
+
+func (b *bellMatcher) Criteria() interface{} {
+	return "bell"
+}
@@ -0,0 +1,54 @@
+package mimic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_BellCount(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	assert.Equal(t, 0, m.BellCount())
+	_, err = m.Device().Write([]byte("done\a" +
+		"\x1b]0;window title\a" + // BEL terminating an OSC sequence isn't a bell
+		"\x1bPq#0\a" + // nor one terminating a DCS string
+		"\x1b]777;notify;Build;finished\x1b\\"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, m.BellCount(), "BEL and OSC 777 notifications should be counted")
+}
+
+func TestMimic_ExpectBell(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = m.Device().Write([]byte("working...\a"))
+	}()
+	assert.NoError(t, m.ExpectBell())
+	assert.ErrorIs(t, m.ExpectBell(), ErrExpectTimeout, "each bell should satisfy one expectation")
+
+	_, err = m.Device().Write([]byte("\a\a"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, m.BellCount())
+	assert.NoError(t, m.ExpectBell(), "bells rung before the expectation should satisfy it")
+	assert.NoError(t, m.ExpectBell())
+	assert.Error(t, m.ExpectBell())
+}
+
+func TestControlParser(t *testing.T) {
+	var parser controlParser
+	var sequences []string
+	for _, b := range []byte("\x1b]8;;https://example.com\x1b\\link\x1b]8;;\a\a\x1b]52;c;aGk=\x1b[m\x1b]2;t\x18\a\x1bP+q544e\x1b\\") {
+		if ctl, ok := parser.feed(b); ok {
+			sequences = append(sequences, string(ctl.kind)+string(ctl.payload))
+		}
+	}
+	assert.Equal(t, []string{"]8;;https://example.com", "]8;;", "\a", "[m", "\a", "P+q544e"}, sequences,
+		"unterminated and aborted sequences shouldn't complete")
+}
@@ -0,0 +1,98 @@
+package mimic
+
+import "strings"
+
+// ContainsBlock determines if the emulated terminal's view contains lines on consecutive rows, in order. The lines and
+// rows are each dedented, i.e. the indentation they share is removed, and trailing whitespace is trimmed before they're
+// compared, so a block matches wherever it's rendered while its relative indentation is still verified:
+//
+//	m.ContainsBlock(
+//		"Usage:",
+//		"  mimic [flags]",
+//		"",
+//		"Flags:",
+//		"  -h, --help   help for mimic",
+//	)
+//
+// Lines may also be separated by newlines, e.g. in a raw string literal, in which case blank lines at the start and end
+// of the block are ignored. Rows retained by WithScrollback precede the view's rows.
+func (m *Mimic) ContainsBlock(lines ...string) bool {
+	block := dedent(blockLines(lines))
+	err := m.Flush()
+	if err != nil {
+		m.debug("ContainsBlock failed to flush", "error", err)
+		m.transcript.record("ContainsBlock", lines, "", err)
+		return false
+	}
+
+	v := Viewer{Mimic: m, StripAnsi: true, Trim: true}
+	contents := m.searchable(strings.Join(v.Lines(), "\n"))
+	rows := strings.Split(contents, "\n")
+
+	found := false
+	for start := 0; len(block) > 0 && start+len(block) <= len(rows) && !found; start++ {
+		found = equalLines(dedent(rows[start:start+len(block)]), block)
+	}
+
+	if !found {
+		m.transcript.record("ContainsBlock", lines, contents, notContained([]string{strings.Join(block, "\n")}))
+		m.debug("ContainsBlock failed", "block", strings.Join(block, "\n"))
+		return false
+	}
+	m.transcript.record("ContainsBlock", lines, contents, nil)
+	m.strict.consumeMatching(func(line string) bool {
+		line = strings.TrimSpace(line)
+		for _, expected := range block {
+			if line != "" && line == strings.TrimSpace(expected) {
+				return true
+			}
+		}
+		return false
+	})
+	return true
+}
+
+// blockLines splits lines containing newlines, ignoring blank lines at the start and end of the block
+func blockLines(lines []string) []string {
+	split := strings.Split(strings.Join(lines, "\n"), "\n")
+	for len(split) > 0 && strings.TrimSpace(split[0]) == "" {
+		split = split[1:]
+	}
+	for len(split) > 0 && strings.TrimSpace(split[len(split)-1]) == "" {
+		split = split[:len(split)-1]
+	}
+	return split
+}
+
+// dedent trims trailing whitespace from lines, and removes the indentation shared by lines which aren't blank
+func dedent(lines []string) []string {
+	trimmed := make([]string, len(lines))
+	indent := -1
+	for i, line := range lines {
+		trimmed[i] = strings.TrimRight(line, " \t")
+		if trimmed[i] == "" {
+			continue
+		}
+		if n := len(trimmed[i]) - len(strings.TrimLeft(trimmed[i], " \t")); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	for i, line := range trimmed {
+		if line != "" {
+			trimmed[i] = line[indent:]
+		}
+	}
+	return trimmed
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package mimic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_ContainsBlock(t *testing.T) {
+	m, err := NewMimic(WithSize(10, 40), WithIdleTimeout(50*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Tty().WriteString("$ mimic --help\r\n" +
+		"    Usage:\r\n" +
+		"      mimic [flags]   \r\n" +
+		"\r\n" +
+		"    Flags:\r\n" +
+		"      -h, --help   help for mimic\r\n")
+	assert.NoError(t, err)
+
+	assert.True(t, m.ContainsBlock(
+		"Usage:",
+		"  mimic [flags]",
+		"",
+		"Flags:",
+	), "the block should match regardless of its indentation in the view")
+	assert.True(t, m.ContainsBlock(`
+		Flags:
+		  -h, --help   help for mimic
+	`), "lines separated by newlines should be dedented")
+	assert.True(t, m.ContainsBlock("mimic [flags]"))
+
+	assert.False(t, m.ContainsBlock("Usage:", "mimic [flags]"), "relative indentation should be verified")
+	assert.False(t, m.ContainsBlock("Usage:", "  mimic [flags]", "Flags:"), "lines should be on consecutive rows")
+	assert.False(t, m.ContainsBlock("Flags:", "Usage:"), "lines should be in order")
+	assert.False(t, m.ContainsBlock())
+}
+
+func TestDedent(t *testing.T) {
+	assert.Equal(t, []string{"a", "", "  b", "c"}, dedent([]string{"  a  ", "", "    b", "  c"}))
+	assert.Equal(t, []string{"x"}, dedent([]string{"x"}))
+}
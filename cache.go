@@ -0,0 +1,71 @@
+package mimic
+
+import (
+	"container/list"
+	"regexp"
+	"sync"
+)
+
+// regexpCacheSize bounds the number of compiled patterns retained by compiledPatterns
+const regexpCacheSize = 512
+
+// compiledPatterns retains recently compiled patterns, so suites which expect the same patterns repeatedly don't pay
+// for compilation on every call
+var compiledPatterns = newRegexpCache(regexpCacheSize)
+
+// regexpCache is a least-recently-used cache of compiled regular expressions, keyed by their source. A *regexp.Regexp
+// is safe for concurrent use, so cached values are shared by callers.
+type regexpCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type regexpCacheEntry struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+func newRegexpCache(size int) *regexpCache {
+	return &regexpCache{size: size, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// compile returns the cached compilation of pattern, compiling and caching it if necessary. Patterns which fail to
+// compile aren't cached.
+func (c *regexpCache) compile(pattern string) (*regexp.Regexp, error) {
+	c.mu.Lock()
+	if element, ok := c.entries[pattern]; ok {
+		c.order.MoveToFront(element)
+		c.mu.Unlock()
+		return element.Value.(*regexpCacheEntry).re, nil
+	}
+	c.mu.Unlock()
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[pattern]; ok {
+		// compiled concurrently by another caller
+		c.order.MoveToFront(element)
+		return element.Value.(*regexpCacheEntry).re, nil
+	}
+	c.entries[pattern] = c.order.PushFront(&regexpCacheEntry{pattern: pattern, re: re})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*regexpCacheEntry).pattern)
+	}
+	return re, nil
+}
+
+// len is the number of cached patterns
+func (c *regexpCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
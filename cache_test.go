@@ -0,0 +1,67 @@
+package mimic
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegexpCache(t *testing.T) {
+	c := newRegexpCache(2)
+
+	a, err := c.compile(`a+`)
+	assert.NoError(t, err)
+	again, err := c.compile(`a+`)
+	assert.NoError(t, err)
+	assert.Same(t, a, again, "repeated patterns should reuse the compiled regexp")
+
+	_, err = c.compile(`(`)
+	assert.Error(t, err)
+	assert.Equal(t, 1, c.len(), "invalid patterns shouldn't be cached")
+
+	_, _ = c.compile(`b+`)
+	_, _ = c.compile(`a+`) // a+ is now the most recently used
+	_, _ = c.compile(`c+`)
+	assert.Equal(t, 2, c.len())
+
+	again, _ = c.compile(`a+`)
+	assert.Same(t, a, again, "the most recently used pattern should be retained")
+	assert.NotContains(t, c.entries, `b+`, "the least recently used pattern should be evicted")
+}
+
+func TestRegexpCache_concurrent(t *testing.T) {
+	c := newRegexpCache(8)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			re, err := c.compile(fmt.Sprintf(`id-%d`, i%12))
+			assert.NoError(t, err)
+			assert.True(t, re.MatchString(fmt.Sprintf("id-%d", i%12)))
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 8, c.len())
+}
+
+func TestCompilePattern_cached(t *testing.T) {
+	first, err := CompilePattern(`Created user (\d+)`)
+	assert.NoError(t, err)
+	second, err := CompilePattern(`Created user (\d+)`)
+	assert.NoError(t, err)
+	assert.Same(t, first, second)
+
+	assert.NoError(t, RegisterMatcher("build", `\d{4}`))
+	t.Cleanup(func() { UnregisterMatcher("build") })
+	before, err := CompilePattern(`build {{build}}`)
+	assert.NoError(t, err)
+	assert.NoError(t, RegisterMatcher("build", `[a-f0-9]{7}`))
+	after, err := CompilePattern(`build {{build}}`)
+	assert.NoError(t, err)
+	assert.True(t, after.MatchString("build 3e4f1a2"), "re-registering a placeholder should take effect")
+	assert.False(t, before.MatchString("build 3e4f1a2"))
+}
@@ -0,0 +1,38 @@
+package mimic
+
+import "time"
+
+// CallOption adjusts the behavior of individual operations, without affecting the Mimic they were derived from.
+// See Mimic.With.
+type CallOption func(*Mimic)
+
+// WithCallTimeout overrides the idle timeout (see WithIdleTimeout) for expectations such as Mimic.ExpectString and
+// Mimic.ExpectPattern.
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(m *Mimic) {
+		m.maxIdleWait = timeout
+	}
+}
+
+// WithCallFlushTimeout overrides the flush timeout (see WithFlushTimeout) for operations which flush before
+// inspecting the view, such as Mimic.ContainsString and Mimic.ContainsPattern.
+func WithCallFlushTimeout(timeout time.Duration) CallOption {
+	return func(m *Mimic) {
+		m.flushTimeout = timeout
+	}
+}
+
+// With returns a Mimic which shares the emulated terminal of m, but applies opts to operations invoked on it.
+// This allows adjusting a single call without reconfiguring m:
+//
+//	err := m.With(mimic.WithCallTimeout(5 * time.Second)).ExpectString("slow prompt")
+//
+// The returned Mimic is not independent: writes, expectations, and Close affect the same terminal as m.
+func (m *Mimic) With(opts ...CallOption) *Mimic {
+	derived := *m
+	for _, opt := range opts {
+		opt(&derived)
+	}
+	derived.Experimental = exp(derived)
+	return &derived
+}
@@ -0,0 +1,41 @@
+package mimic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_With(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(50 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = m.Tty().WriteString("slow prompt")
+	}()
+
+	assert.Error(t, m.ExpectString("slow prompt"), "default idle timeout should elapse before the prompt")
+	assert.NoError(t, m.With(WithCallTimeout(time.Second)).ExpectString("slow prompt"))
+
+	started := time.Now()
+	assert.Error(t, m.ExpectString("never written"))
+	assert.Less(t, time.Since(started), 500*time.Millisecond, "call options should not affect the original mimic")
+}
+
+func TestMimic_With_flushTimeout(t *testing.T) {
+	m, err := NewMimic(WithFlushTimeout(10 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	go func() {
+		for _, s := range []string{"one ", "two ", "three"} {
+			_, _ = m.Tty().WriteString(s)
+			time.Sleep(50 * time.Millisecond)
+		}
+	}()
+
+	assert.True(t, m.With(WithCallFlushTimeout(200*time.Millisecond)).ContainsString("one two three"))
+}
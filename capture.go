@@ -0,0 +1,53 @@
+package mimic
+
+import (
+	"bytes"
+
+	"github.com/jimschubert/mimic/internal/vt10x"
+)
+
+// WithUnboundedHeight causes the emulated terminal to grow its row count as output reaches the bottom row, rather than
+// scrolling content out of view. This retains the full logical output of non-fullscreen applications in the view,
+// regardless of the configured rows. The pty's window size as seen by the application is unchanged.
+func WithUnboundedHeight() Option {
+	return func(opt *mimicOpt) {
+		opt.grow = true
+		opt.maxRows = 0
+	}
+}
+
+// WithAutoGrow causes the emulated terminal to grow its row count as output reaches the bottom row, up to maxRows.
+// Once maxRows is reached, the terminal scrolls as usual. This avoids guessing a WithSize value for variable-length
+// output while still bounding the view. The pty's window size as seen by the application is unchanged.
+// See WithUnboundedHeight for growth without a cap.
+func WithAutoGrow(maxRows int) Option {
+	return func(opt *mimicOpt) {
+		opt.grow = maxRows > 0
+		opt.maxRows = maxRows
+	}
+}
+
+// growingWriter writes to a terminal, increasing the terminal's rows before a write would scroll content out of view.
+type growingWriter struct {
+	terminal vt10x.Terminal
+	step     int
+	// maxRows caps growth; zero means unbounded
+	maxRows int
+}
+
+func (g *growingWriter) Write(p []byte) (int, error) {
+	columns, rows := g.terminal.Size()
+	required := g.terminal.Cursor().Y + bytes.Count(p, []byte("\n")) + 2
+	if required > rows && (g.maxRows == 0 || rows < g.maxRows) {
+		target := rows + g.step
+		if target < required {
+			target = required
+		}
+		if g.maxRows > 0 && target > g.maxRows {
+			target = g.maxRows
+		}
+		g.terminal.Resize(columns, target)
+	}
+
+	return g.terminal.Write(p)
+}
@@ -0,0 +1,57 @@
+package mimic
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithUnboundedHeight(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		lineCount int
+		wantFirst bool
+	}{
+		{name: "scrolls without unbounded height", opts: []Option{WithSize(5, 20)}, lineCount: 30, wantFirst: false},
+		{name: "retains all output with unbounded height", opts: []Option{WithSize(5, 20), WithUnboundedHeight()}, lineCount: 30, wantFirst: true},
+		{name: "retains output within auto grow limit", opts: []Option{WithSize(5, 20), WithAutoGrow(40)}, lineCount: 30, wantFirst: true},
+		{name: "scrolls beyond auto grow limit", opts: []Option{WithSize(5, 20), WithAutoGrow(12)}, lineCount: 30, wantFirst: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMimic(tt.opts...)
+			assert.NoError(t, err)
+			defer m.Close()
+
+			for i := 1; i <= tt.lineCount; i++ {
+				_, err = fmt.Fprintf(m.Tty(), "line %d\n", i)
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, m.Flush())
+			v := Viewer{Mimic: m, StripAnsi: true, Trim: true}
+			lines := strings.Split(v.String(), "\n")
+
+			assert.Equal(t, fmt.Sprintf("line %d", tt.lineCount), strings.TrimSpace(lines[len(lines)-1]))
+			assert.Equal(t, tt.wantFirst, strings.TrimSpace(lines[0]) == "line 1")
+		})
+	}
+}
+
+func TestWithAutoGrow_limit(t *testing.T) {
+	m, err := NewMimic(WithSize(5, 20), WithAutoGrow(12))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	for i := 1; i <= 30; i++ {
+		_, err = fmt.Fprintf(m.Tty(), "line %d\n", i)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, m.Flush())
+
+	rows, _ := m.Size()
+	assert.Equal(t, 12, rows, "terminal should not grow beyond its limit")
+}
@@ -0,0 +1,178 @@
+package mimic
+
+import (
+	"fmt"
+
+	"github.com/jimschubert/mimic/internal/vt10x"
+	"golang.org/x/text/width"
+)
+
+// Color is a color rendered by the emulated terminal: one of the 16 ANSI colors, an index into the xterm 256-color
+// palette, a 24-bit color (see RGB), or DefaultColor when the application hasn't set a color.
+type Color uint32
+
+// ANSI colors
+const (
+	Black Color = iota
+	Red
+	Green
+	Yellow
+	Blue
+	Magenta
+	Cyan
+	LightGrey
+	DarkGrey
+	LightRed
+	LightGreen
+	LightYellow
+	LightBlue
+	LightMagenta
+	LightCyan
+	White
+)
+
+// DefaultColor is the terminal's default foreground or background color
+const DefaultColor Color = 1 << 24
+
+// rgbColor flags a 24-bit color, distinguishing it from an index into the 256-color palette
+const rgbColor Color = 1 << 25
+
+// RGB returns the 24-bit color with the given components, as set via SGR 38;2;r;g;b or 48;2;r;g;b
+func RGB(r, g, b uint8) Color {
+	return rgbColor | Color(r)<<16 | Color(g)<<8 | Color(b)
+}
+
+// String returns "default" for DefaultColor, the hex code of a 24-bit color (e.g. "#875faf"), or the palette index of
+// other colors (e.g. "color(97)")
+func (c Color) String() string {
+	switch {
+	case c == DefaultColor:
+		return "default"
+	case c&rgbColor != 0:
+		return fmt.Sprintf("#%06x", uint32(c)&0xffffff)
+	default:
+		return fmt.Sprintf("color(%d)", uint32(c))
+	}
+}
+
+// mirrors vt10x's unexported glyph attribute flags (see TestVT10XFlags)
+const (
+	glyphReverse = 1 << iota
+	glyphUnderline
+	glyphBold
+	_ // graphics character set
+	glyphItalic
+	glyphBlink
+	glyphWrap // set on the last cell of a row which wrapped onto the next
+)
+
+// Cell is a single character cell of the emulated terminal's screen, along with its rendering attributes.
+//
+// Foreground and Background are the colors as rendered: they are swapped for Reverse cells, and Bold text in one of the
+// first 8 ANSI colors is rendered with its bright variant (e.g. bold Red renders as LightRed).
+type Cell struct {
+	Rune rune
+	// Width is the number of columns Rune occupies when displayed: 2 for East Asian wide and fullwidth runes, otherwise 1.
+	// The column covered by the right half of a wide rune is a cell with a zero Rune and Width.
+	Width      int
+	Foreground Color
+	Background Color
+	Bold       bool
+	Underline  bool
+	Reverse    bool
+	Italic     bool
+	Blink      bool
+}
+
+// Screen returns the cells of the emulated terminal's screen, indexed by row then column.
+// Pending output is flushed to the terminal first.
+func (m *Mimic) Screen() [][]Cell {
+	_ = m.Flush()
+	return m.screen()
+}
+
+// CellAt returns the cell at the zero-based row and column of the emulated terminal's screen.
+// Pending output is flushed to the terminal first.
+func (m *Mimic) CellAt(row, column int) (Cell, error) {
+	_ = m.Flush()
+
+	m.terminal.Lock()
+	defer m.terminal.Unlock()
+
+	columns, rows := m.terminal.Size()
+	if row < 0 || row >= rows || column < 0 || column >= columns {
+		return Cell{}, fmt.Errorf("cell (row %d, column %d) is outside of the %dx%d screen", row, column, rows, columns)
+	}
+	return newCell(m.terminal.Cell(column, row)), nil
+}
+
+// ForegroundAt returns the rendered foreground color of the cell at the zero-based row and column of the emulated
+// terminal's screen, after flushing pending output. See CellAt.
+//
+//	fg, err := m.ForegroundAt(0, 4)
+//	if err == nil && fg != mimic.RGB(0x87, 0x5f, 0xaf) { ... }
+func (m *Mimic) ForegroundAt(row, column int) (Color, error) {
+	cell, err := m.CellAt(row, column)
+	return cell.Foreground, err
+}
+
+// BackgroundAt returns the rendered background color of the cell at the zero-based row and column of the emulated
+// terminal's screen, after flushing pending output. See CellAt.
+func (m *Mimic) BackgroundAt(row, column int) (Color, error) {
+	cell, err := m.CellAt(row, column)
+	return cell.Background, err
+}
+
+// screen returns the cells of the emulated terminal's screen, indexed by row then column
+func (m *Mimic) screen() [][]Cell {
+	m.terminal.Lock()
+	defer m.terminal.Unlock()
+
+	columns, rows := m.terminal.Size()
+	screen := make([][]Cell, rows)
+	for y := range screen {
+		screen[y] = make([]Cell, columns)
+		for x := range screen[y] {
+			screen[y][x] = newCell(m.terminal.Cell(x, y))
+		}
+	}
+	return screen
+}
+
+func newCell(g vt10x.Glyph) Cell {
+	r, width := g.Char, runeWidth(g.Char)
+	if r == wideSpacer {
+		r, width = 0, 0
+	}
+	return Cell{
+		Rune:       r,
+		Width:      width,
+		Foreground: newColor(g.FG),
+		Background: newColor(g.BG),
+		Bold:       g.Mode&glyphBold != 0,
+		Underline:  g.Mode&glyphUnderline != 0,
+		Reverse:    g.Mode&glyphReverse != 0,
+		Italic:     g.Mode&glyphItalic != 0,
+		Blink:      g.Mode&glyphBlink != 0,
+	}
+}
+
+func runeWidth(r rune) int {
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func newColor(c vt10x.Color) Color {
+	switch c {
+	case vt10x.DefaultFG, vt10x.DefaultBG:
+		return DefaultColor
+	}
+	if c&vt10x.TrueColor != 0 || c > 255 {
+		return rgbColor | Color(c&0xffffff)
+	}
+	return Color(c)
+}
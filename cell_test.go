@@ -0,0 +1,104 @@
+package mimic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_CellAt(t *testing.T) {
+	m, err := NewMimic(WithSize(4, 20))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, _ = m.Tty().WriteString("ok \x1b[31mERR\x1b[0m \x1b[1;4;32mPASS\x1b[0m \x1b[7;33;44mR\x1b[0m \x1b[38;5;200mX")
+
+	tests := []struct {
+		name   string
+		column int
+		want   Cell
+	}{
+		{name: "default", column: 0, want: Cell{Rune: 'o', Width: 1, Foreground: DefaultColor, Background: DefaultColor}},
+		{name: "foreground", column: 3, want: Cell{Rune: 'E', Width: 1, Foreground: Red, Background: DefaultColor}},
+		{name: "bold brightens", column: 7, want: Cell{Rune: 'P', Width: 1, Foreground: LightGreen, Background: DefaultColor, Bold: true, Underline: true}},
+		{name: "reverse swaps colors", column: 12, want: Cell{Rune: 'R', Width: 1, Foreground: Blue, Background: Yellow, Reverse: true}},
+		{name: "256 color", column: 14, want: Cell{Rune: 'X', Width: 1, Foreground: Color(200), Background: DefaultColor}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cell, err := m.CellAt(0, tt.column)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, cell)
+		})
+	}
+
+	_, err = m.CellAt(4, 0)
+	assert.Error(t, err)
+}
+
+func TestMimic_Screen(t *testing.T) {
+	m, err := NewMimic(WithSize(2, 6))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, _ = m.Tty().WriteString("a\x1b[1mb\x1b[0m\r\n世")
+
+	screen := m.Screen()
+	assert.Len(t, screen, 2)
+	assert.Len(t, screen[0], 6)
+	assert.Equal(t, Cell{Rune: 'b', Width: 1, Foreground: DefaultColor, Background: DefaultColor, Bold: true}, screen[0][1])
+	assert.Equal(t, ' ', screen[0][2].Rune)
+	assert.Equal(t, '世', screen[1][0].Rune)
+	assert.Equal(t, 2, screen[1][0].Width)
+}
+
+func TestMimic_ForegroundAt(t *testing.T) {
+	m, err := NewMimic(WithSize(2, 20))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, _ = m.Tty().WriteString("\x1b[38;5;97ma\x1b[38;2;135;95;175mb\x1b[38:2::255:128:0;48:5:17mc\x1b[1;38:2:1:2:3;58:2::9:9:9md\x1b[4:3me\x1b[4:0mf\x1b[38;2;0;0;128;48;2;0;0;5mg")
+
+	tests := []struct {
+		name       string
+		column     int
+		foreground Color
+		background Color
+	}{
+		{name: "256 color", column: 0, foreground: Color(97), background: DefaultColor},
+		{name: "truecolor", column: 1, foreground: RGB(0x87, 0x5f, 0xaf), background: DefaultColor},
+		{name: "colon separated", column: 2, foreground: RGB(255, 128, 0), background: Color(17)},
+		{name: "colon separated without color space", column: 3, foreground: RGB(1, 2, 3), background: Color(17)},
+		{name: "truecolor within the palette's range", column: 6, foreground: RGB(0, 0, 128), background: RGB(0, 0, 5)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			foreground, err := m.ForegroundAt(0, tt.column)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.foreground, foreground)
+			background, err := m.BackgroundAt(0, tt.column)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.background, background)
+		})
+	}
+
+	cell, err := m.CellAt(0, 3)
+	assert.NoError(t, err)
+	assert.True(t, cell.Bold, "underline colors shouldn't reset other attributes")
+	cell, err = m.CellAt(0, 4)
+	assert.NoError(t, err)
+	assert.True(t, cell.Underline && cell.Bold, "underline styles should underline")
+	cell, err = m.CellAt(0, 5)
+	assert.NoError(t, err)
+	assert.False(t, cell.Underline)
+
+	_, err = m.ForegroundAt(2, 0)
+	assert.Error(t, err)
+}
+
+func TestColor_String(t *testing.T) {
+	assert.Equal(t, "default", DefaultColor.String())
+	assert.Equal(t, "color(1)", Red.String())
+	assert.Equal(t, "#00005f", RGB(0, 0, 0x5f).String())
+	assert.NotEqual(t, Color(0x5f), RGB(0, 0, 0x5f))
+}
@@ -0,0 +1,65 @@
+package mimic
+
+import (
+	"encoding/base64"
+	"strings"
+	"sync"
+)
+
+// oscClipboard prefixes the payload of an OSC 52 clipboard sequence, ESC ] 52 ; selection ; data ST
+var oscClipboard = []byte("52;")
+
+// ClipboardWrite is a write to the clipboard via an OSC 52 sequence. See Mimic.ClipboardWrites.
+type ClipboardWrite struct {
+	// Selection names the selections written, e.g. "c" for the clipboard or "p" for the primary selection. Terminals
+	// treat an empty selection as "s0".
+	Selection string
+	// Data is the decoded content written to the selection. Empty data clears the selection.
+	Data string
+}
+
+// clipboard captures OSC 52 clipboard writes from output
+type clipboard struct {
+	mu     sync.Mutex
+	parser controlParser
+	writes []ClipboardWrite
+}
+
+func (c *clipboard) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, b := range p {
+		ctl, ok := c.parser.feed(b)
+		if ok && ctl.is(']', oscClipboard) {
+			c.capture(string(ctl.payload[len(oscClipboard):]))
+		}
+	}
+	return len(p), nil
+}
+
+// capture records the write described by payload, "selection;data". Queries ("selection;?") and data which isn't valid
+// base64 aren't writes, and are ignored.
+func (c *clipboard) capture(payload string) {
+	parts := strings.SplitN(payload, ";", 2)
+	if len(parts) < 2 || parts[1] == "?" {
+		return
+	}
+	data, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return
+	}
+	c.writes = append(c.writes, ClipboardWrite{Selection: parts[0], Data: string(data)})
+}
+
+func (c *clipboard) all() []ClipboardWrite {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]ClipboardWrite(nil), c.writes...)
+}
+
+// ClipboardWrites returns the writes to the clipboard made via OSC 52 sequences so far, in order, after flushing pending
+// output. This allows testing tools which copy values, such as tokens or URLs, to the user's clipboard.
+func (m *Mimic) ClipboardWrites() []ClipboardWrite {
+	_ = m.Flush()
+	return m.clipboard.all()
+}
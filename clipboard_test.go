@@ -0,0 +1,29 @@
+package mimic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_ClipboardWrites(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	assert.Empty(t, m.ClipboardWrites())
+	_, err = m.Device().Write([]byte("Token copied!" +
+		"\x1b]52;c;c2VjcmV0LXRva2Vu\a" +
+		"\x1b]52;c;?\a" + // a query isn't a write
+		"\x1b]52;p;not base64!\a" +
+		"\x1b]52;;\x1b\\"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []ClipboardWrite{
+		{Selection: "c", Data: "secret-token"},
+		{Selection: "", Data: ""},
+	}, m.ClipboardWrites())
+	assert.True(t, m.ContainsString("Token copied!"))
+	assert.False(t, m.ContainsString("c2VjcmV0"), "the payload shouldn't be rendered")
+}
@@ -0,0 +1,97 @@
+// Command mimic runs a command under a pseudo terminal, driving it with a script file and reporting whether each
+// expectation was met. This allows interactive command line applications to be tested from shell-based CI jobs.
+//
+// Usage:
+//
+//	mimic [flags] -script <file> -- <command> [args…]
+//
+// See parse for the script file format.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/jimschubert/mimic"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the CLI, returning its exit code: 0 if the script passed, 1 if it failed, and 2 for usage errors
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("mimic", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	scriptPath := flags.String("script", "", "path to the script file (required)")
+	timeout := flags.Duration("timeout", mimic.DefaultIdleTimeout, "default timeout of each step")
+	rows := flags.Int("rows", mimic.DefaultRows, "rows of the emulated terminal")
+	columns := flags.Int("columns", mimic.DefaultColumns, "columns of the emulated terminal")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *scriptPath == "" || flags.NArg() == 0 {
+		fmt.Fprintln(stderr, "usage: mimic [flags] -script <file> -- <command> [args…]")
+		flags.PrintDefaults()
+		return 2
+	}
+
+	f, err := os.Open(*scriptPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "unable to open script: %v\n", err)
+		return 2
+	}
+	defer f.Close()
+
+	m, err := mimic.NewMimic(mimic.WithSize(*rows, *columns), mimic.WithIdleTimeout(*timeout))
+	if err != nil {
+		fmt.Fprintf(stderr, "unable to create terminal: %v\n", err)
+		return 2
+	}
+	defer m.Close()
+
+	p, err := parse(f, m)
+	if err != nil {
+		fmt.Fprintf(stderr, "invalid script %s: %v\n", *scriptPath, err)
+		return 2
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	process, err := m.Spawn(ctx, flags.Arg(0), flags.Args()[1:]...)
+	if err != nil {
+		fmt.Fprintf(stderr, "unable to start %s: %v\n", flags.Arg(0), err)
+		return 2
+	}
+
+	if err := p.script.Run(ctx); err != nil {
+		return fail(stdout, m, err)
+	}
+
+	if p.exitCode != nil {
+		select {
+		case <-process.Done():
+		case <-time.After(*timeout):
+			return fail(stdout, m, errors.New("command did not exit"))
+		}
+		if code := process.ExitCode(); code != *p.exitCode {
+			return fail(stdout, m, fmt.Errorf("command exited with %d, expected %d", code, *p.exitCode))
+		}
+	}
+
+	fmt.Fprintf(stdout, "PASS: %d step(s)\n", p.steps)
+	return 0
+}
+
+// fail reports err along with the terminal's view
+func fail(w io.Writer, m *mimic.Mimic, err error) int {
+	_ = m.Flush()
+	v := mimic.Viewer{Mimic: m, StripAnsi: true, Trim: true}
+	fmt.Fprintf(w, "FAIL: %v\n\nterminal view:\n%s\n", err, v.String())
+	return 1
+}
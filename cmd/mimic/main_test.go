@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jimschubert/mimic"
+	"github.com/stretchr/testify/assert"
+)
+
+const program = `printf "name? "; read name; printf "color? "; read color; echo "$name likes $color"; exit 3`
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   string
+		want     int
+		contains string
+	}{
+		{
+			name:     "passes",
+			script:   "# answers each prompt\nexpect name?\nsend Tom\nexpect_pattern color\\?\nsend blue\ntimeout 2s\nexpect Tom likes blue\nexit 3\n",
+			want:     0,
+			contains: "PASS: 5 step(s)",
+		},
+		{
+			name:     "failed expectation",
+			script:   "expect name?\nsend Tom\nexpect age?\ntimeout 100ms\n",
+			want:     1,
+			contains: "FAIL: step 3 (Expect(\"age?\"))",
+		},
+		{
+			name:     "unexpected exit code",
+			script:   "expect name?\nsend Tom\nexpect color?\nsend red\nexit 0\n",
+			want:     1,
+			contains: "FAIL: command exited with 3, expected 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "script")
+			assert.NoError(t, os.WriteFile(path, []byte(tt.script), 0o600))
+
+			var stdout, stderr bytes.Buffer
+			code := run([]string{"-script", path, "-timeout", "1s", "--", "sh", "-c", program}, &stdout, &stderr)
+			assert.Equal(t, tt.want, code, stderr.String())
+			assert.Contains(t, stdout.String(), tt.contains)
+		})
+	}
+}
+
+func TestRun_usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 2, run([]string{"sh"}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "usage: mimic")
+}
+
+func TestParse_errors(t *testing.T) {
+	m, err := mimic.NewMimic()
+	assert.NoError(t, err)
+	defer m.Close()
+
+	tests := []struct {
+		script string
+		want   string
+	}{
+		{script: "bogus thing", want: `line 1: unknown directive "bogus"`},
+		{script: "\nexpect", want: "line 2: expect requires an argument"},
+		{script: "timeout 1s", want: "line 1: timeout must follow a step"},
+		{script: "send x\ntimeout soon", want: "line 2: "},
+		{script: "key ArrowDown Nope", want: `line 1: unknown key "Nope"`},
+		{script: "exit 0\nexpect x", want: "line 2: exit must be the final directive"},
+	}
+	for _, tt := range tests {
+		_, err := parse(strings.NewReader(tt.script), m)
+		assert.ErrorContains(t, err, tt.want)
+	}
+}
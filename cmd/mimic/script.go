@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jimschubert/mimic"
+)
+
+// keys maps the names accepted by the key directive to their sequences
+var keys = map[string]mimic.Key{
+	"Enter":      mimic.KeyEnter,
+	"Tab":        mimic.KeyTab,
+	"Backspace":  mimic.KeyBackspace,
+	"Escape":     mimic.KeyEscape,
+	"Space":      mimic.KeySpace,
+	"ArrowUp":    mimic.KeyArrowUp,
+	"ArrowDown":  mimic.KeyArrowDown,
+	"ArrowRight": mimic.KeyArrowRight,
+	"ArrowLeft":  mimic.KeyArrowLeft,
+	"Home":       mimic.KeyHome,
+	"End":        mimic.KeyEnd,
+	"Insert":     mimic.KeyInsert,
+	"Delete":     mimic.KeyDelete,
+	"PageUp":     mimic.KeyPageUp,
+	"PageDown":   mimic.KeyPageDown,
+	"F1":         mimic.KeyF1,
+	"F2":         mimic.KeyF2,
+	"F3":         mimic.KeyF3,
+	"F4":         mimic.KeyF4,
+	"F5":         mimic.KeyF5,
+	"F6":         mimic.KeyF6,
+	"F7":         mimic.KeyF7,
+	"F8":         mimic.KeyF8,
+	"F9":         mimic.KeyF9,
+	"F10":        mimic.KeyF10,
+	"F11":        mimic.KeyF11,
+	"F12":        mimic.KeyF12,
+}
+
+// plan is a parsed script file
+type plan struct {
+	script *mimic.Script
+	// steps is the number of steps in script
+	steps int
+	// exitCode is the expected exit code of the command, or nil if not asserted
+	exitCode *int
+}
+
+// parse reads a script file of one directive per line. Blank lines and lines beginning with # are ignored.
+//
+//	expect <text>            wait for text to be output
+//	expect_pattern <regex>   wait for output to match a pattern
+//	send <text>              type text, followed by enter
+//	key <name> [<name>…]     press named keys, e.g. ArrowDown Enter
+//	timeout <duration>       override the timeout of the preceding step, e.g. 5s
+//	exit <code>              expect the command to exit with code; must be the final directive
+func parse(r io.Reader, m *mimic.Mimic) (*plan, error) {
+	p := &plan{script: mimic.NewScript(m)}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if p.exitCode != nil {
+			return nil, fmt.Errorf("line %d: exit must be the final directive", line)
+		}
+
+		directive, argument, _ := strings.Cut(text, " ")
+		argument = strings.TrimSpace(argument)
+		if argument == "" {
+			return nil, fmt.Errorf("line %d: %s requires an argument", line, directive)
+		}
+
+		switch directive {
+		case "expect":
+			p.script.Expect(argument)
+			p.steps++
+		case "expect_pattern":
+			p.script.ExpectPattern(argument)
+			p.steps++
+		case "send":
+			p.script.Send(argument)
+			p.steps++
+		case "key":
+			var sequence []mimic.Key
+			for _, name := range strings.Fields(argument) {
+				key, ok := keys[name]
+				if !ok {
+					return nil, fmt.Errorf("line %d: unknown key %q", line, name)
+				}
+				sequence = append(sequence, key)
+			}
+			p.script.SendKey(sequence...)
+			p.steps++
+		case "timeout":
+			if p.steps == 0 {
+				return nil, fmt.Errorf("line %d: timeout must follow a step", line)
+			}
+			d, err := time.ParseDuration(argument)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			p.script.Timeout(d)
+		case "exit":
+			code, err := strconv.Atoi(argument)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid exit code %q", line, argument)
+			}
+			p.exitCode = &code
+		default:
+			return nil, fmt.Errorf("line %d: unknown directive %q", line, directive)
+		}
+	}
+	return p, scanner.Err()
+}
@@ -0,0 +1,127 @@
+package mimic
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/jimschubert/mimic/internal"
+)
+
+// Text creates a Matcher which matches once the output contains s, compared as Mimic.ExpectString does, including the
+// Mimic's matching options (e.g. WithCaseInsensitive and WithPlaceholders).
+func Text(s string) Matcher {
+	return textMatcher{s: s}
+}
+
+type textMatcher struct {
+	s string
+}
+
+func (t textMatcher) Match(view StreamView) bool {
+	if view.m == nil {
+		return strings.Contains(view.Output, t.s)
+	}
+	return view.m.stringMatcher(t.s).Match(bytes.NewBufferString(view.Raw))
+}
+
+func (t textMatcher) Criteria() string {
+	return t.s
+}
+
+// Regexp creates a Matcher which matches once the output matches re, as Mimic.ExpectRegexp does. A nil re never
+// matches. See CompilePattern to compile a pattern containing {{name}} placeholders.
+func Regexp(re *regexp.Regexp) Matcher {
+	return regexpMatcher{re: re}
+}
+
+type regexpMatcher struct {
+	re *regexp.Regexp
+}
+
+func (r regexpMatcher) Match(view StreamView) bool {
+	if r.re == nil {
+		return false
+	}
+	return (&internal.RegexpMatcher{Re: r.re}).Match(bytes.NewBufferString(view.Raw))
+}
+
+func (r regexpMatcher) Criteria() string {
+	if r.re == nil {
+		return "<nil>"
+	}
+	return r.re.String()
+}
+
+// All creates a Matcher which matches once every one of matchers matches the same view, e.g. to require that output
+// contains a banner and a prompt in any order. All matches immediately if matchers is empty. Nil matchers never match.
+func All(matchers ...Matcher) Matcher {
+	return combinedMatcher{operation: "all", matchers: matchers, any: false}
+}
+
+// Any creates a Matcher which matches once any of matchers matches, e.g. to wait for either a success banner or a retry
+// prompt. Any never matches if matchers is empty. Nil matchers never match.
+//
+//	_, err := m.ExpectMatcher(mimic.Any(mimic.Text("Deployed"), mimic.Text("Retry? [y/N]")))
+//
+// Mimic.ExpectMatcher reports which of its matchers matched, so pass the alternatives to it directly when the path
+// taken matters; Any is most useful when nested, e.g. within All.
+func Any(matchers ...Matcher) Matcher {
+	return combinedMatcher{operation: "any", matchers: matchers, any: true}
+}
+
+type combinedMatcher struct {
+	operation string
+	matchers  []Matcher
+	any       bool
+}
+
+func (c combinedMatcher) Match(view StreamView) bool {
+	for _, matcher := range c.matchers {
+		matched := matcher != nil && matcher.Match(view)
+		if matched == c.any {
+			return matched
+		}
+	}
+	return !c.any
+}
+
+func (c combinedMatcher) Criteria() string {
+	criteria := make([]string, 0, len(c.matchers))
+	for _, matcher := range c.matchers {
+		criteria = append(criteria, criteriaOfMatcher(matcher))
+	}
+	return c.operation + "(" + strings.Join(criteria, ", ") + ")"
+}
+
+// Not creates a Matcher which matches whenever matcher doesn't. As matchers are evaluated as output arrives, Not
+// matches as soon as it's first evaluated unless matcher already matches, so it's most useful within All, e.g. to wait
+// for "Done" on output which doesn't contain "error":
+//
+//	_, err := m.ExpectMatcher(mimic.All(mimic.Text("Done"), mimic.Not(mimic.Text("error"))))
+//
+// To fail when output appears within a period of time, see Mimic.ExpectNotString. A nil matcher is treated as never
+// matching, so Not(nil) always matches.
+func Not(matcher Matcher) Matcher {
+	return notMatcher{matcher: matcher}
+}
+
+type notMatcher struct {
+	matcher Matcher
+}
+
+func (n notMatcher) Match(view StreamView) bool {
+	return n.matcher == nil || !n.matcher.Match(view)
+}
+
+func (n notMatcher) Criteria() string {
+	return "not(" + criteriaOfMatcher(n.matcher) + ")"
+}
+
+// criteriaOfMatcher describes matcher, which may be nil
+func criteriaOfMatcher(matcher Matcher) string {
+	if matcher == nil {
+		return "<nil>"
+	}
+	return matcher.Criteria()
+}
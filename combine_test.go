@@ -0,0 +1,57 @@
+package mimic
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCombinedMatchers(t *testing.T) {
+	view := StreamView{Output: "Deployed v1.2.3", Raw: "\x1b[32mDeployed\x1b[0m v1.2.3"}
+	deployed, retry := Text("Deployed"), Text("Retry?")
+	version := Regexp(regexp.MustCompile(`v\d+\.\d+`))
+
+	tests := []struct {
+		matcher  Matcher
+		want     bool
+		criteria string
+	}{
+		{matcher: All(deployed, version), want: true, criteria: `all(Deployed, v\d+\.\d+)`},
+		{matcher: All(deployed, retry), want: false, criteria: "all(Deployed, Retry?)"},
+		{matcher: Any(retry, deployed), want: true, criteria: "any(Retry?, Deployed)"},
+		{matcher: Any(retry, nil), want: false, criteria: "any(Retry?, <nil>)"},
+		{matcher: Not(retry), want: true, criteria: "not(Retry?)"},
+		{matcher: All(deployed, Not(Any(retry, Text("error")))), want: true, criteria: "all(Deployed, not(any(Retry?, error)))"},
+		{matcher: All(), want: true, criteria: "all()"},
+		{matcher: Any(), want: false, criteria: "any()"},
+		{matcher: Regexp(nil), want: false, criteria: "<nil>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.criteria, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.matcher.Match(view))
+			assert.Equal(t, tt.criteria, tt.matcher.Criteria())
+		})
+	}
+}
+
+func TestMimic_ExpectMatcher_combined(t *testing.T) {
+	m, err := NewMimic(WithCaseInsensitive(), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = m.Tty().WriteString("Connection failed.\r\nRETRY? [y/N] ")
+	}()
+
+	success := Text("Deployed")
+	prompt := All(Text("retry?"), Not(Text("fatal")))
+	matched, err := m.ExpectMatcher(Any(success, prompt))
+	assert.NoError(t, err, "Text should honor the Mimic's matching options")
+	assert.Equal(t, "any(Deployed, all(retry?, not(fatal)))", matched.Criteria())
+
+	_, err = m.ExpectMatcher(All(Text("Deployed"), Text("[y/N]")))
+	assert.ErrorIs(t, err, ErrExpectTimeout)
+}
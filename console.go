@@ -0,0 +1,172 @@
+package mimic
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jimschubert/mimic/internal"
+	"github.com/jimschubert/mimic/internal/expect"
+)
+
+// ErrNoPty is returned by operations which require a pseudo terminal, such as Mimic.Spawn, when the Mimic emulates its
+// terminal in memory (see WithInMemoryPty)
+var ErrNoPty = errors.New("mimic: no pty when emulating the terminal in memory")
+
+// console is the byte pipeline between a Mimic and the program under test. It's fulfilled by go-expect's
+// *expect.Console, backed by a pty, and by memoryConsole.
+type console interface {
+	Expect(opts ...expect.ExpectOpt) (string, error)
+	ExpectEOF() (string, error)
+	Send(s string) (int, error)
+	Close() error
+}
+
+// expectConsole reads output via the console's Expect. Output is written to the taps of the console as it's read, a
+// rune at a time, so taps which coalesce output (e.g. events and recordings) are flushed once the read ends. The caller
+// must hold m.reading.
+func (m *Mimic) expectConsole(opts ...expect.ExpectOpt) (string, error) {
+	defer m.endRead()
+	return m.console.Expect(opts...)
+}
+
+// endRead flushes the output coalesced by taps during a read of the console
+func (m *Mimic) endRead() {
+	m.events.flush()
+	m.recording.flush()
+}
+
+// ptyConsole is a console backed by a pty, i.e. go-expect's *expect.Console
+type ptyConsole interface {
+	console
+	Tty() *os.File
+	Fd() uintptr
+}
+
+// WithInMemoryPty emulates the terminal entirely in memory, rather than opening a pseudo terminal (i.e. /dev/ptmx).
+// This allows use of Mimic in containers, sandboxes, and on platforms where opening a pty fails. Terminals are always
+// emulated in memory under js/wasm, which has no ptys.
+//
+// Programs under test read input from and write output to Mimic.Device, as there's no tty: Mimic.Tty returns nil, and
+// Mimic.TtyFile, Mimic.PtyFd, Mimic.Stdio and operations which require a pty, such as Mimic.Spawn, return ErrNoPty.
+// There's no line discipline, so input isn't echoed and line endings aren't translated. Expectations and assertions
+// upon the view work as they do with a pty.
+func WithInMemoryPty() Option {
+	return func(opt *mimicOpt) {
+		opt.inMemory = true
+	}
+}
+
+// memoryConsole emulates the byte pipeline of go-expect's Console in memory. Input sent to the console is read by the
+// program under test via device; output written to device is read by Expect.
+type memoryConsole struct {
+	input      *internal.Pipe
+	output     *internal.Pipe
+	device     *memoryDevice
+	runeReader *bufio.Reader
+	stdOut     []io.Writer
+	logger     *log.Logger
+}
+
+func newMemoryConsole(logger *log.Logger) *memoryConsole {
+	input, output := internal.NewPipe(), internal.NewPipe()
+	if logger == nil {
+		logger = log.New(io.Discard, "", 0)
+	}
+	return &memoryConsole{
+		input:      input,
+		output:     output,
+		device:     &memoryDevice{input: input, output: output},
+		runeReader: bufio.NewReaderSize(output, utf8.UTFMax),
+		logger:     logger,
+	}
+}
+
+// start copies each of stdIn to the console's input, and writes output read by Expect to each of stdOut
+func (c *memoryConsole) start(stdIn []io.Reader, stdOut []io.Writer) {
+	c.stdOut = stdOut
+	for _, in := range stdIn {
+		go func(in io.Reader) {
+			if _, err := io.Copy(c.input, in); err != nil {
+				c.logger.Printf("failed to copy stdin: %s", err)
+			}
+		}(in)
+	}
+}
+
+// Expect reads output until a condition specified by opts is met, mirroring expect.Console.Expect
+func (c *memoryConsole) Expect(opts ...expect.ExpectOpt) (string, error) {
+	var options expect.ExpectOpts
+	for _, opt := range opts {
+		if err := opt(&options); err != nil {
+			return "", err
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	writer := io.MultiWriter(append(c.stdOut, buf)...)
+	for {
+		if options.ReadTimeout != nil {
+			_ = c.output.SetReadDeadline(time.Now().Add(*options.ReadTimeout))
+		}
+
+		r, _, err := c.runeReader.ReadRune()
+		if err != nil {
+			if options.Match(err) != nil {
+				return buf.String(), nil
+			}
+			return buf.String(), err
+		}
+
+		c.logger.Printf("expect read: %q", string(r))
+		if _, err := writer.Write([]byte(string(r))); err != nil {
+			return buf.String(), err
+		}
+
+		if matcher := options.Match(buf); matcher != nil {
+			if cb, ok := matcher.(expect.CallbackMatcher); ok {
+				return buf.String(), cb.Callback(buf)
+			}
+			return buf.String(), nil
+		}
+	}
+}
+
+func (c *memoryConsole) ExpectEOF() (string, error) {
+	return c.Expect(expect.EOF, expect.PTSClosed)
+}
+
+func (c *memoryConsole) Send(s string) (int, error) {
+	c.logger.Printf("console send: %q", s)
+	return io.WriteString(c.input, s)
+}
+
+func (c *memoryConsole) Close() error {
+	_ = c.input.Close()
+	return c.output.Close()
+}
+
+// memoryDevice is the program's side of a memoryConsole: reads receive input sent to the console, and writes are
+// output for the console to read
+type memoryDevice struct {
+	input  *internal.Pipe
+	output *internal.Pipe
+}
+
+func (d *memoryDevice) Read(p []byte) (int, error) {
+	return d.input.Read(p)
+}
+
+func (d *memoryDevice) Write(p []byte) (int, error) {
+	return d.output.Write(p)
+}
+
+// Close ends the program's output, as closing a tty does
+func (d *memoryDevice) Close() error {
+	return d.output.Close()
+}
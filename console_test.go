@@ -0,0 +1,70 @@
+package mimic
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithInMemoryPty(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithSize(5, 20), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	assert.Nil(t, m.Tty())
+	go func() {
+		// a program which greets each line of input
+		device := m.Device()
+		_, _ = fmt.Fprint(device, "name? ")
+		scanner := bufio.NewScanner(device)
+		for scanner.Scan() {
+			_, _ = fmt.Fprintf(device, "\r\nHello, %s\r\n", scanner.Text())
+		}
+	}()
+
+	assert.NoError(t, m.ExpectString("name?"))
+	_, err = m.WriteString("Tom\n")
+	assert.NoError(t, err)
+	assert.NoError(t, m.ExpectString("Hello, Tom"))
+	assert.True(t, m.ContainsString("name?", "Hello, Tom"))
+	assert.ErrorIs(t, m.ExpectString("Goodbye"), ErrExpectTimeout)
+
+	row, column := m.Cursor()
+	assert.Equal(t, 2, row)
+	assert.Equal(t, 0, column)
+	assert.NoError(t, m.Resize(10, 40), "resizing only affects the view")
+}
+
+func TestWithInMemoryPty_requiresPty(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty())
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Spawn(context.Background(), "true")
+	assert.ErrorIs(t, err, ErrNoPty)
+	_, err = m.Experimental.Console()
+	assert.ErrorIs(t, err, ErrNoPty)
+	_, err = m.TtyFile()
+	assert.ErrorIs(t, err, ErrNoPty)
+	_, err = m.PtyFd()
+	assert.ErrorIs(t, err, ErrNoPty)
+	_, err = m.Stdio()
+	assert.ErrorIs(t, err, ErrNoPty)
+}
+
+func TestWithInMemoryPty_shutdown(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+
+	_, err = m.Device().Write([]byte("unread output"))
+	assert.NoError(t, err)
+	assert.NoError(t, m.Shutdown(context.Background()))
+	assert.True(t, m.ContainsString("unread output"), "output should be drained into the view")
+
+	_, err = m.WriteString("closed")
+	assert.Error(t, err)
+}
@@ -0,0 +1,142 @@
+package mimic
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/jimschubert/mimic/internal/vt10x"
+)
+
+// Cursor returns the zero-based row and column of the emulated terminal's cursor, i.e. where the next output will be
+// written. Pending output is flushed to the terminal first.
+func (m *Mimic) Cursor() (row, column int) {
+	_ = m.Flush()
+	return m.cursor()
+}
+
+// CursorStyle is the shape of the cursor, as set via DECSCUSR (ESC [ Ps SP q). The values match the sequence's
+// parameter.
+type CursorStyle int
+
+const (
+	// CursorStyleDefault is the terminal's default cursor shape, typically a blinking block. This is the style until
+	// an application sets one.
+	CursorStyleDefault CursorStyle = iota
+	CursorBlinkingBlock
+	CursorSteadyBlock
+	CursorBlinkingUnderline
+	CursorSteadyUnderline
+	CursorBlinkingBar
+	CursorSteadyBar
+)
+
+// String returns the name of the cursor style
+func (s CursorStyle) String() string {
+	switch s {
+	case CursorStyleDefault:
+		return "default"
+	case CursorBlinkingBlock:
+		return "blinking-block"
+	case CursorSteadyBlock:
+		return "steady-block"
+	case CursorBlinkingUnderline:
+		return "blinking-underline"
+	case CursorSteadyUnderline:
+		return "steady-underline"
+	case CursorBlinkingBar:
+		return "blinking-bar"
+	case CursorSteadyBar:
+		return "steady-bar"
+	default:
+		return "unknown"
+	}
+}
+
+// CursorVisible reports whether the emulated terminal's cursor is visible, i.e. it hasn't been hidden via DECTCEM
+// (ESC [ ? 25 l). Prompt libraries commonly hide the cursor while rendering, and should show it again before exiting.
+// Pending output is flushed to the terminal first.
+func (m *Mimic) CursorVisible() bool {
+	_ = m.Flush()
+	m.terminal.Lock()
+	defer m.terminal.Unlock()
+	return m.terminal.CursorVisible()
+}
+
+// CursorStyle returns the shape of the emulated terminal's cursor, as last set via DECSCUSR, after flushing pending
+// output.
+func (m *Mimic) CursorStyle() CursorStyle {
+	_ = m.Flush()
+	return m.cursorShape.get()
+}
+
+// WaitForCursorAt waits for the emulated terminal's cursor to arrive at the zero-based row and column, returning an
+// error if it doesn't move there before the idle timeout (see WithIdleTimeout and WithCallTimeout).
+func (m *Mimic) WaitForCursorAt(row, column int) error {
+	_, _, err := m.expect(context.Background(), &cursorMatcher{m: m, row: row, column: column})
+	if err != nil {
+		actualRow, actualColumn := m.cursor()
+		return fmt.Errorf("cursor not at (row %d, column %d), last seen at (row %d, column %d): %w", row, column, actualRow, actualColumn, err)
+	}
+	return nil
+}
+
+func (m *Mimic) cursor() (row, column int) {
+	c := m.terminalCursor()
+	return c.Y, c.X
+}
+
+// terminalCursor reads the emulated terminal's cursor, which may be moving as output is read on another goroutine
+func (m *Mimic) terminalCursor() vt10x.Cursor {
+	m.terminal.Lock()
+	defer m.terminal.Unlock()
+	return m.terminal.Cursor()
+}
+
+// cursorMatcher matches once the terminal's cursor is at the expected position, regardless of the content read
+type cursorMatcher struct {
+	m           *Mimic
+	row, column int
+}
+
+func (c *cursorMatcher) Match(_ interface{}) bool {
+	row, column := c.m.cursor()
+	return row == c.row && column == c.column
+}
+
+func (c *cursorMatcher) Criteria() interface{} {
+	return [2]int{c.row, c.column}
+}
+
+// cursorShape tracks the cursor style set via DECSCUSR
+type cursorShape struct {
+	mu     sync.Mutex
+	parser controlParser
+	style  CursorStyle
+}
+
+func (c *cursorShape) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, b := range p {
+		ctl, ok := c.parser.feed(b)
+		if !ok || ctl.kind != '[' || !bytes.HasSuffix(ctl.payload, []byte(" q")) {
+			continue
+		}
+		param := string(ctl.payload[:len(ctl.payload)-2])
+		if param == "" {
+			c.style = CursorStyleDefault
+		} else if n, err := strconv.Atoi(param); err == nil && n >= 0 && n <= int(CursorSteadyBar) {
+			c.style = CursorStyle(n)
+		}
+	}
+	return len(p), nil
+}
+
+func (c *cursorShape) get() CursorStyle {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.style
+}
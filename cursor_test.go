@@ -0,0 +1,69 @@
+package mimic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_Cursor(t *testing.T) {
+	m, err := NewMimic()
+	assert.NoError(t, err)
+	defer m.Close()
+
+	row, column := m.Cursor()
+	assert.Equal(t, 0, row)
+	assert.Equal(t, 0, column)
+
+	_, _ = m.Tty().WriteString("first\r\nName: ")
+	row, column = m.Cursor()
+	assert.Equal(t, 1, row)
+	assert.Equal(t, 6, column)
+}
+
+func TestMimic_WaitForCursorAt(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(100 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = m.Tty().WriteString("Loading…\r\n")
+		time.Sleep(20 * time.Millisecond)
+		_, _ = m.Tty().WriteString("\x1b[5;3H")
+	}()
+
+	assert.NoError(t, m.WaitForCursorAt(4, 2))
+	assert.NoError(t, m.WaitForCursorAt(4, 2), "should match immediately when the cursor is already in position")
+	assert.ErrorContains(t, m.WaitForCursorAt(0, 0), "last seen at (row 4, column 2)")
+}
+
+func TestMimic_CursorVisible(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	assert.True(t, m.CursorVisible())
+	_, _ = m.Device().Write([]byte("\x1b[?25lLoading…"))
+	assert.False(t, m.CursorVisible())
+	_, _ = m.Device().Write([]byte("\r\x1b[K\x1b[?25h"))
+	assert.True(t, m.CursorVisible(), "the cursor should be visible once restored")
+}
+
+func TestMimic_CursorStyle(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	assert.Equal(t, CursorStyleDefault, m.CursorStyle())
+	_, _ = m.Device().Write([]byte("\x1b[6 q"))
+	assert.Equal(t, CursorSteadyBar, m.CursorStyle())
+	_, _ = m.Device().Write([]byte("\x1b[4 q"))
+	assert.Equal(t, CursorSteadyUnderline, m.CursorStyle())
+	_, _ = m.Device().Write([]byte("\x1b[9 q"))
+	assert.Equal(t, CursorSteadyUnderline, m.CursorStyle(), "unknown styles should be ignored")
+	_, _ = m.Device().Write([]byte("\x1b[ q"))
+	assert.Equal(t, CursorStyleDefault, m.CursorStyle())
+	assert.Equal(t, "steady-bar", CursorSteadyBar.String())
+}
@@ -0,0 +1,31 @@
+package mimic
+
+import (
+	"os"
+
+	"github.com/jimschubert/mimic/internal"
+)
+
+// NoColorEnv is the environment variable which, when set to a non-empty value, disables colorized diffs by default.
+// See https://no-color.org and WithDiffColor.
+const NoColorEnv = "NO_COLOR"
+
+// WithDiffColor determines whether diffs in failure output (see Mimic.Diff and Mimic.MatchSnapshot) are colorized with
+// ANSI escape sequences. Diffs are colorized by default, unless the NO_COLOR environment variable is set.
+func WithDiffColor(enabled bool) Option {
+	return func(opt *mimicOpt) {
+		opt.diffColor = enabled
+	}
+}
+
+// Diff is a line-level unified diff between expected and actual view content, such as the rows of the view (see
+// Viewer.Lines) joined by newlines. The diff is colorized unless disabled via WithDiffColor. An empty string is
+// returned if expected and actual are equal.
+func (m *Mimic) Diff(expected, actual string) string {
+	return internal.UnifiedDiff(expected, actual, "Expected", "Actual", m.diffColor)
+}
+
+// diffColorDefault reports whether diffs are colorized when WithDiffColor isn't specified
+func diffColorDefault() bool {
+	return os.Getenv(NoColorEnv) == ""
+}
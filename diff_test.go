@@ -0,0 +1,39 @@
+package mimic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_Diff(t *testing.T) {
+	t.Setenv(NoColorEnv, "")
+	m, err := NewMimic()
+	assert.NoError(t, err)
+	defer m.Close()
+
+	assert.Equal(t,
+		"\x1b[1m--- Expected\x1b[0m\n\x1b[1m+++ Actual\x1b[0m\n\x1b[36m@@ -1,2 +1,2 @@\x1b[0m\n Hello\n\x1b[31m-World\x1b[0m\n\x1b[32m+Word\x1b[0m",
+		m.Diff("Hello\nWorld", "Hello\nWord"),
+	)
+	assert.Empty(t, m.Diff("Hello", "Hello"))
+}
+
+func TestWithDiffColor(t *testing.T) {
+	t.Setenv(NoColorEnv, "")
+	m, err := NewMimic(WithDiffColor(false))
+	assert.NoError(t, err)
+	defer m.Close()
+	assert.Equal(t, "--- Expected\n+++ Actual\n@@ -1 +1 @@\n-World\n+Word", m.Diff("World", "Word"))
+
+	t.Setenv(NoColorEnv, "1")
+	m, err = NewMimic()
+	assert.NoError(t, err)
+	defer m.Close()
+	assert.NotContains(t, m.Diff("World", "Word"), "\x1b[", "NO_COLOR should disable color by default")
+
+	m, err = NewMimic(WithDiffColor(true))
+	assert.NoError(t, err)
+	defer m.Close()
+	assert.Contains(t, m.Diff("World", "Word"), "\x1b[31m-World", "WithDiffColor should take precedence over NO_COLOR")
+}
@@ -1,21 +1,189 @@
 package mimic
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strings"
+
+	"github.com/jimschubert/mimic/internal"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+var (
+	// ErrClosed is returned by operations on a closed Mimic, and by expectations which are abandoned because the Mimic
+	// was closed
+	ErrClosed = errors.New("mimic: closed")
+	// ErrExpectTimeout is returned when an expectation isn't met before the idle timeout (see WithIdleTimeout)
+	ErrExpectTimeout = errors.New("mimic: expectation timed out")
+	// ErrEOF is returned when output ends before an expectation is met
+	ErrEOF = errors.New("mimic: end of output")
+	// ErrInvalidPattern is returned when a pattern isn't a valid regular expression, or a compiled regular expression is
+	// nil
+	ErrInvalidPattern = errors.New("mimic: invalid pattern")
+	// ErrNilMatcher is returned by custom expectations (see Mimic.ExpectMatcher) given a nil Matcher or function
+	ErrNilMatcher = errors.New("mimic: nil matcher")
 )
 
+// consoleError associates an error from the underlying console or pty with one of the sentinel errors, so that callers
+// may branch on errors.Is(err, ErrExpectTimeout) rather than the details of the underlying error, which remains
+// available via errors.Unwrap.
+type consoleError struct {
+	sentinel error
+	err      error
+}
+
+func (c *consoleError) Error() string {
+	return fmt.Sprintf("%v: %v", c.sentinel, c.err)
+}
+
+func (c *consoleError) Is(target error) bool {
+	return target == c.sentinel
+}
+
+func (c *consoleError) Unwrap() error {
+	return c.err
+}
+
+// Timeout allows os.IsTimeout to continue identifying wrapped read timeouts
+func (c *consoleError) Timeout() bool {
+	return os.IsTimeout(c.err)
+}
+
+// wrapConsoleError associates err with the sentinel error describing it, if any. Context errors are returned as-is, as
+// they describe the caller's cancellation rather than the console.
+func wrapConsoleError(err error) error {
+	var wrapped *consoleError
+	switch {
+	case err == nil, errors.As(err, &wrapped), errors.Is(err, ErrClosed):
+		return err
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return err
+	case errors.Is(err, os.ErrClosed):
+		return &consoleError{sentinel: ErrClosed, err: err}
+	case os.IsTimeout(err):
+		return &consoleError{sentinel: ErrExpectTimeout, err: err}
+	case errors.Is(err, io.EOF):
+		return &consoleError{sentinel: ErrEOF, err: err}
+	}
+	return err
+}
+
+// errorViewRows is the number of rows of the view included in a PatternError for a timed out expectation
+const errorViewRows = 10
+
+// nearMissRatio is the minimum similarity (from 0 to 1) of a row to an expected string for the row to be reported as a
+// near miss
+const nearMissRatio = 0.6
+
+// PatternError is returned by expectations (e.g. Mimic.ExpectString and Mimic.ExpectPattern) which fail to match.
+// The reason for the failure, such as ErrExpectTimeout, is available via errors.Is and errors.Unwrap.
 type PatternError struct {
-	Contents       string
+	// Contents holds the output observed during the expectation, stripped of ANSI escape characters
+	Contents string
+	// FailedPatterns are the strings or patterns which failed to match
 	FailedPatterns []string
+	// Err is the reason the expectation ended without a match
+	Err error
+	// View holds the last rows of the terminal's formatted view when the expectation timed out, numbered by row
+	View string
+	// NearMiss is a diff between an expected string and the most similar row of the view, if any row closely resembles
+	// an expected string
+	NearMiss string
 }
 
 func (p PatternError) Error() string {
 	var suffix string
 	count := len(p.FailedPatterns)
-	if count > 0 {
+	if count != 1 {
 		suffix = "s"
 	}
 
-	return fmt.Sprintf("contents failed to match %d pattern%s: %v", count, suffix, strings.Join(p.FailedPatterns, ", "))
+	message := fmt.Sprintf("contents failed to match %d pattern%s: %v", count, suffix, strings.Join(p.FailedPatterns, ", "))
+	if p.Err != nil {
+		message += ": " + p.Err.Error()
+	}
+	if p.View != "" {
+		message += "\n\nView:\n" + p.View
+	}
+	if p.NearMiss != "" {
+		message += "\n\nNearest row:\n" + p.NearMiss
+	}
+	return message
+}
+
+func (p PatternError) Unwrap() error {
+	return p.Err
+}
+
+// patternError describes the failure of an expectation for criteria, or returns nil if err is nil. When the expectation
+// timed out, the error includes the end of the view, and a near miss for each of literals (the criteria which are
+// plain strings) that closely resembles a row of the view.
+func (m *Mimic) patternError(criteria []string, contents string, err error, literals bool) error {
+	if err == nil {
+		return nil
+	}
+
+	patternErr := PatternError{Contents: contents, FailedPatterns: criteria, Err: err}
+	if !errors.Is(err, ErrExpectTimeout) {
+		return patternErr
+	}
+
+	v := Viewer{Mimic: m, StripAnsi: true, Trim: true}
+	lines := strings.Split(strings.TrimSuffix(trimView(v.Lines()), "\n"), "\n")
+	patternErr.View = FormatScreen(lines, errorViewRows)
+
+	if literals {
+		var nearMisses []string
+		for _, expected := range criteria {
+			row, ok := nearestRow(expected, lines)
+			switch {
+			case !ok:
+			case strings.Contains(lines[row], expected):
+				nearMisses = append(nearMisses, fmt.Sprintf("View row %d contains %q, but it was read by an earlier expectation", row, expected))
+			default:
+				nearMisses = append(nearMisses, internal.UnifiedDiff(expected, lines[row], "Expected", fmt.Sprintf("View row %d", row), false))
+			}
+		}
+		patternErr.NearMiss = strings.Join(nearMisses, "\n")
+	}
+	return patternErr
+}
+
+// nearestRow finds the row of lines most similar to expected, reporting false if no row is similar enough to be a near
+// miss. Rows longer than expected are compared by their most similar window of expected's length, so that a near miss
+// is found within a row containing other content.
+func nearestRow(expected string, lines []string) (row int, ok bool) {
+	want := strings.Split(expected, "")
+	best := 0.0
+	for i, line := range lines {
+		runes := []rune(line)
+		width := len([]rune(expected))
+		for start := 0; start == 0 || start+width <= len(runes); start++ {
+			end := start + width
+			if end > len(runes) {
+				end = len(runes)
+			}
+			ratio := difflib.NewMatcher(want, strings.Split(string(runes[start:end]), "")).Ratio()
+			if ratio > best {
+				best, row = ratio, i
+			}
+		}
+	}
+	return row, best >= nearMissRatio
+}
+
+// UnexpectedOutputError is returned by negative expectations (e.g. Mimic.ExpectNotString) when output matching
+// forbidden criteria is observed.
+type UnexpectedOutputError struct {
+	// Criteria is the string or pattern which matched
+	Criteria string
+	// Contents holds the output observed during the expectation, stripped of ANSI escape characters
+	Contents string
+}
+
+func (u UnexpectedOutputError) Error() string {
+	return fmt.Sprintf("output unexpectedly matched %q", u.Criteria)
 }
@@ -0,0 +1,129 @@
+package mimic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_wrapConsoleError(t *testing.T) {
+	timeout := &os.PathError{Op: "read", Path: "/dev/ptmx", Err: os.ErrDeadlineExceeded}
+	closed := &os.PathError{Op: "write", Path: "/dev/ptmx", Err: os.ErrClosed}
+	other := errors.New("something else")
+
+	tests := []struct {
+		name     string
+		err      error
+		sentinel error
+	}{
+		{name: "read timeout", err: timeout, sentinel: ErrExpectTimeout},
+		{name: "end of output", err: io.EOF, sentinel: ErrEOF},
+		{name: "closed file", err: closed, sentinel: ErrClosed},
+		{name: "already closed", err: ErrClosed, sentinel: ErrClosed},
+		{name: "context deadline", err: context.DeadlineExceeded, sentinel: context.DeadlineExceeded},
+		{name: "unrecognized", err: other, sentinel: other},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := wrapConsoleError(tt.err)
+			assert.ErrorIs(t, wrapped, tt.sentinel)
+			assert.ErrorIs(t, wrapped, tt.err, "the underlying error should remain available")
+			assert.Equal(t, wrapped, wrapConsoleError(wrapped), "wrapping should not be repeated")
+		})
+	}
+
+	assert.NoError(t, wrapConsoleError(nil))
+	assert.True(t, os.IsTimeout(wrapConsoleError(timeout)), "wrapped timeouts should satisfy os.IsTimeout")
+	assert.Equal(t, "mimic: expectation timed out: read /dev/ptmx: i/o timeout", wrapConsoleError(timeout).Error())
+}
+
+func TestMimic_sentinelErrors(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(20 * time.Millisecond))
+	assert.NoError(t, err)
+
+	err = m.ExpectString("never written")
+	assert.ErrorIs(t, err, ErrExpectTimeout)
+
+	_, err = m.ExpectPattern(`never\s+written`)
+	assert.ErrorIs(t, err, ErrExpectTimeout)
+
+	assert.NoError(t, m.Close())
+	_, err = m.WriteString("closed")
+	assert.ErrorIs(t, err, ErrClosed, fmt.Sprintf("unexpected error: %v", err))
+}
+
+func TestPatternError(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(20 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Tty().WriteString("\x1b[1mHello\x1b[0m, World")
+	assert.NoError(t, err)
+
+	_, err = m.ExpectPattern(`Goodbye`, `Farewell\s`)
+	var patternErr PatternError
+	if assert.ErrorAs(t, err, &patternErr) {
+		assert.Equal(t, []string{`Goodbye`, `Farewell\s`}, patternErr.FailedPatterns)
+		assert.Equal(t, "Hello, World", patternErr.Contents)
+	}
+	assert.ErrorIs(t, err, ErrExpectTimeout)
+	assert.ErrorContains(t, err, `contents failed to match 2 patterns: Goodbye, Farewell\s: mimic: expectation timed out`)
+
+	err = m.ExpectString("Hello")
+	if assert.ErrorAs(t, err, &patternErr) {
+		assert.Equal(t, []string{"Hello"}, patternErr.FailedPatterns)
+		assert.Empty(t, patternErr.Contents, "output was consumed by the previous expectation")
+	}
+	assert.ErrorContains(t, err, "contents failed to match 1 pattern: Hello")
+}
+
+func TestPatternError_timeoutIncludesView(t *testing.T) {
+	m, err := NewMimic(WithSize(20, 40), WithIdleTimeout(20*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	for i := 0; i < 12; i++ {
+		_, _ = fmt.Fprintf(m.Tty(), "line %d\r\n", i)
+	}
+	_, err = m.Tty().WriteString("Enter your pasword: ")
+	assert.NoError(t, err)
+
+	err = m.ExpectString("Enter your password:")
+	var patternErr PatternError
+	if !assert.ErrorAs(t, err, &patternErr) {
+		return
+	}
+
+	assert.Equal(t, strings.Join([]string{
+		"  3 | line 3",
+		"  4 | line 4",
+		"  5 | line 5",
+		"  6 | line 6",
+		"  7 | line 7",
+		"  8 | line 8",
+		"  9 | line 9",
+		" 10 | line 10",
+		" 11 | line 11",
+		" 12 | Enter your pasword:",
+	}, "\n"), patternErr.View)
+	assert.Equal(t, "--- Expected\n+++ View row 12\n@@ -1 +1 @@\n-Enter your password:\n+Enter your pasword:", patternErr.NearMiss)
+	assert.Contains(t, err.Error(), "View:\n  3 | line 3")
+	assert.Contains(t, err.Error(), "Nearest row:\n--- Expected")
+
+	err = m.ExpectString("line 11")
+	if assert.ErrorAs(t, err, &patternErr) {
+		assert.Equal(t, `View row 11 contains "line 11", but it was read by an earlier expectation`, patternErr.NearMiss)
+	}
+
+	err = m.ExpectString("something entirely different")
+	if assert.ErrorAs(t, err, &patternErr) {
+		assert.Empty(t, patternErr.NearMiss)
+	}
+}
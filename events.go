@@ -0,0 +1,159 @@
+package mimic
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jimschubert/mimic/internal/vt10x"
+)
+
+// eventBuffer is the capacity of each channel returned by Mimic.Events
+const eventBuffer = 256
+
+// EventKind identifies the kind of an Event
+type EventKind int
+
+const (
+	// EventOutput is the output read from the terminal into the view by a single read
+	EventOutput EventKind = iota
+	// EventScreenChange is a change in the contents of the view, following the EventOutput of the read which changed it
+	EventScreenChange
+)
+
+// String returns the name of the event kind
+func (k EventKind) String() string {
+	switch k {
+	case EventOutput:
+		return "output"
+	case EventScreenChange:
+		return "screen-change"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is an update to the emulated terminal, as delivered by Mimic.Events
+type Event struct {
+	// Kind of update
+	Kind EventKind
+	// Time at which the update occurred
+	Time time.Time
+	// Data read from the terminal, including any ANSI sequences, for EventOutput events
+	Data []byte
+	// Screen is the contents of the view after the change, for EventScreenChange events
+	Screen string
+}
+
+// events publishes updates to subscribers of Mimic.Events
+type events struct {
+	mu          sync.Mutex
+	terminal    vt10x.Terminal
+	subscribers []chan Event
+	screen      string
+	pending     []byte
+	at          time.Time
+	closed      bool
+}
+
+func (e *events) subscribe() <-chan Event {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ch := make(chan Event, eventBuffer)
+	if e.closed {
+		close(ch)
+		return ch
+	}
+	if len(e.subscribers) == 0 {
+		e.screen = withoutSpacers(e.terminal.String())
+	}
+	e.subscribers = append(e.subscribers, ch)
+	return ch
+}
+
+// Write accumulates output until the read which wrote it ends (see events.flush), as output is written as it's read,
+// a rune at a time
+func (e *events) Write(p []byte) (int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.subscribers) == 0 {
+		return len(p), nil
+	}
+	if len(e.pending) == 0 {
+		e.at = time.Now()
+	}
+	e.pending = append(e.pending, p...)
+	return len(p), nil
+}
+
+// flush publishes the output accumulated during a read as a single EventOutput, followed by an EventScreenChange if
+// the view changed
+func (e *events) flush() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.pending) == 0 || len(e.subscribers) == 0 {
+		e.pending = nil
+		return
+	}
+
+	e.publish(Event{Kind: EventOutput, Time: e.at, Data: e.pending})
+	e.pending = nil
+	if screen := withoutSpacers(e.terminal.String()); screen != e.screen {
+		e.screen = screen
+		e.publish(Event{Kind: EventScreenChange, Time: time.Now(), Screen: screen})
+	}
+}
+
+// publish delivers event to each subscriber, discarding the oldest event of a subscriber whose buffer is full so that
+// the latest state is always delivered; the caller must hold mu
+func (e *events) publish(event Event) {
+	for _, ch := range e.subscribers {
+		for delivered := false; !delivered; {
+			select {
+			case ch <- event:
+				delivered = true
+			default:
+				select {
+				case <-ch:
+				default:
+				}
+			}
+		}
+	}
+}
+
+func (e *events) close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return
+	}
+	e.closed = true
+	for _, ch := range e.subscribers {
+		close(ch)
+	}
+	e.subscribers = nil
+}
+
+// Events returns a channel which receives an EventOutput for each read of output from the terminal, and an
+// EventScreenChange whenever that output changes the contents of the view.
+//
+// Nothing reads output on its own: output is read into the view by expectations, Flush, and the other operations which
+// read from the terminal, so events are only delivered while such an operation is in progress, e.g. on another
+// goroutine:
+//
+//	events := m.Events()
+//	go func() {
+//		_ = m.ExpectString("Done")
+//	}()
+//	for event := range events {
+//		if event.Kind == mimic.EventScreenChange && strings.Contains(event.Screen, "Done") {
+//			break
+//		}
+//	}
+//
+// Each call returns a new channel, buffered to hold 256 events. Rather than blocking reads, the oldest event of a
+// channel whose buffer is full is discarded, so the latest screen is always delivered. Channels are closed when the
+// Mimic is closed.
+func (m *Mimic) Events() <-chan Event {
+	return m.events.subscribe()
+}
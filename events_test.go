@@ -0,0 +1,88 @@
+package mimic
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_Events(t *testing.T) {
+	m, err := NewMimic(WithSize(5, 20), WithIdleTimeout(50*time.Millisecond))
+	assert.NoError(t, err)
+
+	events := m.Events()
+	_, err = m.Tty().WriteString("Hello")
+	assert.NoError(t, err)
+	assert.NoError(t, m.ExpectString("Hello"))
+
+	var output strings.Builder
+	var screens []string
+	timeout := time.After(time.Second)
+	for len(screens) == 0 || !strings.Contains(screens[len(screens)-1], "Hello") {
+		select {
+		case event := <-events:
+			switch event.Kind {
+			case EventOutput:
+				output.Write(event.Data)
+			case EventScreenChange:
+				screens = append(screens, event.Screen)
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for screen change, got %q", screens)
+		}
+	}
+	assert.Equal(t, "Hello", output.String())
+
+	assert.NoError(t, m.Close())
+	for range events {
+		// drain until closed
+	}
+	_, open := <-m.Events()
+	assert.False(t, open, "channels requested after Close should be closed")
+}
+
+func TestMimic_Events_coalesced(t *testing.T) {
+	m, err := NewMimic(WithSize(24, 80), WithIdleTimeout(time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	events := m.Events()
+	for i := 0; i < 20; i++ {
+		_, err = m.Tty().WriteString(fmt.Sprintf("line %02d of output\r\n", i))
+		assert.NoError(t, err)
+	}
+	_, err = m.Tty().WriteString("Done")
+	assert.NoError(t, err)
+	assert.NoError(t, m.ExpectString("Done"))
+
+	var received int
+	var screen string
+	for len(events) > 0 {
+		event := <-events
+		received++
+		if event.Kind == EventScreenChange {
+			screen = event.Screen
+		}
+	}
+	assert.Less(t, received, 20, "output should be published once per read, not once per rune")
+	assert.Contains(t, screen, "Done", "the latest screen should be delivered")
+}
+
+func TestEvents_publish_full(t *testing.T) {
+	ch := make(chan Event, 2)
+	e := &events{subscribers: []chan Event{ch}}
+	for _, screen := range []string{"one", "two", "three"} {
+		e.publish(Event{Kind: EventScreenChange, Screen: screen})
+	}
+	assert.Equal(t, "two", (<-ch).Screen)
+	assert.Equal(t, "three", (<-ch).Screen, "the oldest event should be discarded for the latest")
+}
+
+func TestEventKind_String(t *testing.T) {
+	assert.Equal(t, "output", EventOutput.String())
+	assert.Equal(t, "screen-change", EventScreenChange.String())
+	assert.Equal(t, "unknown", EventKind(42).String())
+}
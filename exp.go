@@ -1,10 +1,8 @@
 package mimic
 
 import (
-	"errors"
-
-	"github.com/Netflix/go-expect"
-	"github.com/hinshun/vt10x"
+	"github.com/jimschubert/mimic/internal/expect"
+	"github.com/jimschubert/mimic/internal/vt10x"
 )
 
 // An Experimental contract which can be changed or removed at any time.
@@ -18,14 +16,6 @@ type Experimental interface {
 
 type exp Mimic
 
-// Console provides access to the underlying expect.Console
-func (e exp) Console() (expect.Console, error) {
-	if e.console == nil {
-		return expect.Console{}, errors.New("console is uninitialized")
-	}
-	return *e.console, nil
-}
-
 // Terminal provides access to the underlying vt10x.Terminal
 func (e exp) Terminal() (vt10x.Terminal, error) {
 	return e.terminal, nil
@@ -0,0 +1,226 @@
+package mimic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/jimschubert/mimic/internal"
+	"github.com/jimschubert/mimic/internal/expect"
+	"github.com/jimschubert/stripansi"
+)
+
+// expectPollInterval bounds how long a single read of the underlying console may block, which determines how quickly
+// an expectation observes cancellation while no output is arriving.
+const expectPollInterval = 10 * time.Millisecond
+
+// ExpectStringContext waits for the emulated terminal's view to contain one or more specified strings.
+// The expectation is abandoned with ctx's error if ctx is done before a match is found.
+func (m *Mimic) ExpectStringContext(ctx context.Context, str ...string) error {
+	matchers := make([]expect.Matcher, 0, len(str))
+	for _, s := range str {
+		matchers = append(matchers, m.stringMatcher(s))
+	}
+	output, _, err := m.expect(ctx, matchers...)
+	output = stripansi.String(output)
+	err = m.patternError(str, output, err, true)
+	m.transcript.record("ExpectString", str, output, err)
+	return err
+}
+
+// ExpectPatternContext waits for the emulated terminal's view to contain one or more specified patterns, returning
+// the result of the first pattern to match. The expectation is abandoned with ctx's error if ctx is done before a
+// match is found.
+//
+// Patterns are evaluated as output arrives, so a match is reported as soon as the output satisfies a pattern. For
+// example, `id: \d+` matches once the first digit of an id is written. Anchor captures with a trailing delimiter
+// (e.g. `id: (\d+)\s`) to capture complete values.
+//
+// An error wrapping ErrInvalidPattern is returned, without waiting, if any pattern is invalid.
+func (m *Mimic) ExpectPatternContext(ctx context.Context, pattern ...string) (MatchResult, error) {
+	regexes, err := compilePatterns(pattern)
+	if err != nil {
+		m.transcript.record("ExpectPattern", pattern, "", err)
+		return MatchResult{}, err
+	}
+	return m.expectRegexps(ctx, "ExpectPattern", pattern, regexes)
+}
+
+// ExpectRegexp waits for the emulated terminal's view to match one or more compiled regular expressions, returning the
+// result of the first to match. See Mimic.ExpectRegexpContext.
+func (m *Mimic) ExpectRegexp(regex ...*regexp.Regexp) (MatchResult, error) {
+	return m.ExpectRegexpContext(context.Background(), regex...)
+}
+
+// ExpectRegexpContext is Mimic.ExpectPatternContext for compiled regular expressions, whose MatchResult.Pattern is the
+// source of the regex which matched. Registered {{name}} placeholders aren't expanded. An error wrapping
+// ErrInvalidPattern is returned, without waiting, if any regex is nil.
+func (m *Mimic) ExpectRegexpContext(ctx context.Context, regex ...*regexp.Regexp) (MatchResult, error) {
+	criteria, err := regexpCriteria(regex)
+	if err != nil {
+		m.transcript.record("ExpectRegexp", nil, "", err)
+		return MatchResult{}, err
+	}
+	return m.expectRegexps(ctx, "ExpectRegexp", criteria, regex)
+}
+
+// expectRegexps waits for output to match any of regexes, compiled from criteria, on behalf of operation
+func (m *Mimic) expectRegexps(ctx context.Context, operation string, criteria []string, regexes []*regexp.Regexp) (MatchResult, error) {
+	matchers := make([]expect.Matcher, 0, len(regexes))
+	for _, re := range regexes {
+		matchers = append(matchers, &internal.RegexpMatcher{Re: re})
+	}
+	output, matched, err := m.expect(ctx, matchers...)
+	output = stripansi.String(output)
+	err = m.patternError(criteria, output, err, false)
+	m.transcript.record(operation, criteria, output, err)
+	if err != nil {
+		return MatchResult{}, err
+	}
+
+	for i, matcher := range matchers {
+		if matcher == matched {
+			return newMatchResult(criteria[i], regexes[i], output), nil
+		}
+	}
+	return MatchResult{}, nil
+}
+
+// expect reads output from the console until any of matchers match, no output has arrived for the idle timeout, or ctx
+// is done. Reads are performed in slices of expectPollInterval so that cancellation is observed promptly; content read
+// in earlier slices is carried forward so that matchers evaluate the expectation's full output, as a single
+// Console.Expect would. The full output read during the expectation is returned, along with the matcher which matched.
+// Concurrent expectations are serialized, as each consumes the output it reads. If the Mimic is closed during the
+// expectation, ErrClosed is returned within a poll interval. If the Mimic has a deadline (see NewTestMimic), the
+// expectation is abandoned with ErrExpectTimeout once the deadline passes.
+func (m *Mimic) expect(ctx context.Context, matchers ...expect.Matcher) (output string, matched expect.Matcher, err error) {
+	m.reading.Lock()
+	began := time.Now()
+	if m.trace != nil {
+		m.trace.record(TraceEvent{Event: TraceExpect, Criteria: criteriaOf(matchers...)})
+	}
+	defer func() {
+		m.reading.Unlock()
+		err = wrapConsoleError(err)
+		if m.trace != nil {
+			if matched != nil {
+				m.trace.completed(TraceMatch, began, criteriaOf(matched), nil)
+			} else {
+				m.trace.completed(TraceMismatch, began, nil, err)
+			}
+		}
+		m.notifyExpectation(matchers, matched, output, began, err)
+		m.strict.read(output)
+		if matched != nil {
+			m.strict.consumeMatch(output, matched)
+		}
+	}()
+	if !m.deadline.IsZero() {
+		// runs before the deferred reporting above, so that abandoning at the deadline is reported as a timeout
+		parent := ctx
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, m.deadline)
+		defer func() {
+			cancel()
+			if errors.Is(err, context.DeadlineExceeded) && parent.Err() == nil {
+				err = fmt.Errorf("%w: abandoned at the deadline of %s", ErrExpectTimeout, m.deadline.Format(time.RFC3339Nano))
+			}
+		}()
+	}
+	return m.readMatching(ctx, matchers...)
+}
+
+// watch reads output until any of matchers match, no output has arrived for the idle timeout, or ctx is done, like
+// Mimic.expect, but without reporting the outcome as an expectation: it isn't counted in Stats, handlers registered
+// via OnMatch and OnTimeout aren't notified, and matched output isn't consumed in strict mode. This suits checks
+// which succeed when nothing matches, such as Mimic.ExpectNotString.
+func (m *Mimic) watch(ctx context.Context, matchers ...expect.Matcher) (output string, matched expect.Matcher, err error) {
+	m.reading.Lock()
+	defer func() {
+		m.reading.Unlock()
+		err = wrapConsoleError(err)
+		m.strict.read(output)
+	}()
+	return m.readMatching(ctx, matchers...)
+}
+
+// readMatching performs the reads of Mimic.expect and Mimic.watch, for which the caller holds m.reading
+func (m *Mimic) readMatching(ctx context.Context, matchers ...expect.Matcher) (string, expect.Matcher, error) {
+	carried := internal.NewRingBuffer(m.maxBuffer)
+	carriers := make([]expect.Matcher, 0, len(matchers))
+	for _, matcher := range matchers {
+		carriers = append(carriers, &internal.CarryMatcher{Carried: carried, Matcher: matcher})
+	}
+	matchedCarrier := func() expect.Matcher {
+		for _, carrier := range carriers {
+			if c := carrier.(*internal.CarryMatcher); c.Matched {
+				return c.Matcher
+			}
+		}
+		return nil
+	}
+
+	lastActivity := time.Now()
+	for {
+		if m.IsClosed() {
+			return carried.String(), nil, ErrClosed
+		}
+		if err := ctx.Err(); err != nil {
+			return carried.String(), nil, err
+		}
+
+		timeout := &internal.TimeoutMatcher{}
+		slice := m.maxIdleWait - time.Since(lastActivity)
+		if slice > expectPollInterval {
+			slice = expectPollInterval
+		}
+
+		buf, err := m.expectConsole(expect.WithTimeout(slice), func(opts *expect.ExpectOpts) error {
+			opts.Matchers = append(opts.Matchers, carriers...)
+			opts.Matchers = append(opts.Matchers, &internal.ContextMatcher{Ctx: ctx}, timeout)
+			return nil
+		})
+		carried.WriteString(buf)
+		if len(buf) > 0 {
+			lastActivity = time.Now()
+		}
+
+		if err != nil {
+			if m.IsClosed() {
+				// reads fail once the pty is closed; report why rather than the underlying file error
+				return carried.String(), nil, ErrClosed
+			}
+			return carried.String(), nil, err
+		}
+		if matcher := matchedCarrier(); matcher != nil {
+			return carried.String(), matcher, nil
+		}
+		if timeout.Err != nil && time.Since(lastActivity) >= m.maxIdleWait {
+			return carried.String(), nil, timeout.Err
+		}
+	}
+}
+
+// notifyExpectation counts the outcome of an expectation and reports it to handlers registered via OnMatch and
+// OnTimeout
+func (m *Mimic) notifyExpectation(matchers []expect.Matcher, matched expect.Matcher, output string, began time.Time, err error) {
+	timedOut := matched == nil && errors.Is(err, ErrExpectTimeout)
+	m.stats.expected(matched != nil, timedOut, began)
+	switch {
+	case matched != nil:
+		m.observers.match(Match{
+			Criteria: criteriaOf(matched)[0],
+			Output:   stripansi.String(output),
+			Duration: time.Since(began),
+		})
+	case timedOut:
+		m.observers.timeout(Expectation{
+			Criteria: criteriaOf(matchers...),
+			Output:   stripansi.String(output),
+			Duration: time.Since(began),
+			Err:      err,
+		})
+	}
+}
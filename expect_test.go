@@ -0,0 +1,96 @@
+package mimic
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_ExpectStringContext(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(5 * time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	started := time.Now()
+	assert.ErrorIs(t, m.ExpectStringContext(ctx, "never written"), context.Canceled)
+	assert.Less(t, time.Since(started), time.Second, "cancellation should not wait out the idle timeout")
+}
+
+func TestMimic_ExpectPatternContext(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(5 * time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	go func() {
+		// matches must span reads across multiple poll intervals
+		for _, s := range []string{"Your ", "id ", "is ", "42"} {
+			_, _ = m.Tty().WriteString(s)
+			time.Sleep(3 * expectPollInterval)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	result, err := m.ExpectPatternContext(ctx, `Your (?P<name>\w+) is (\d)`)
+	assert.NoError(t, err)
+	assert.Equal(t, "Your id is 4", result.Text)
+	assert.Equal(t, []string{"id", "4"}, result.Groups)
+	assert.Equal(t, "id", result.Group("name"))
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = m.ExpectPatternContext(ctx, `never written`)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestMimic_ExpectPattern_result(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(100 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Tty().WriteString("\x1b[32mCreated\x1b[0m user 0f8fad5b-d9cb-469f-a165-70867728950e.")
+	assert.NoError(t, err)
+
+	result, err := m.ExpectPattern(`Deleted (\w+)`, `Created user ({{uuid}})\.`)
+	assert.NoError(t, err)
+	assert.Equal(t, `Created user ({{uuid}})\.`, result.Pattern)
+	assert.Equal(t, "Created user 0f8fad5b-d9cb-469f-a165-70867728950e.", result.Text)
+	assert.Equal(t, []string{"0f8fad5b-d9cb-469f-a165-70867728950e"}, result.Groups)
+	assert.Equal(t, "", result.Group("missing"))
+}
+
+func TestMimic_ExpectPattern_invalid(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(5 * time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	started := time.Now()
+	_, err = m.ExpectPattern(`valid`, `(unclosed`)
+	assert.ErrorIs(t, err, ErrInvalidPattern)
+	assert.ErrorContains(t, err, `"(unclosed"`)
+	assert.Less(t, time.Since(started), time.Second, "an invalid pattern should fail without waiting")
+}
+
+func TestMimic_ExpectRegexp(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(100 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Tty().WriteString("Listening on port 8080.")
+	assert.NoError(t, err)
+
+	port := regexp.MustCompile(`port (\d+)\.`)
+	result, err := m.ExpectRegexp(regexp.MustCompile(`failed`), port)
+	assert.NoError(t, err)
+	assert.Equal(t, `port (\d+)\.`, result.Pattern)
+	assert.Equal(t, []string{"8080"}, result.Groups)
+
+	_, err = m.ExpectRegexp(nil)
+	assert.ErrorIs(t, err, ErrInvalidPattern)
+}
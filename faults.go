@@ -0,0 +1,117 @@
+package mimic
+
+import (
+	"io"
+	"sync/atomic"
+)
+
+// FaultHook decides the outcome of a read or write of the terminal's byte stream, given the data to write or the buffer
+// to read into. It returns the number of bytes to transfer, at most len(p), and an error with which the operation
+// fails. Returning len(p) and a nil error lets the operation proceed normally. See FaultInjection.
+type FaultHook func(p []byte) (n int, err error)
+
+// FaultInjection holds hooks which inject failures into the terminal's byte stream. See WithFaultInjection.
+type FaultInjection struct {
+	// Write is consulted before each write of input to the terminal via Mimic.Write or Mimic.WriteString, and of output
+	// to Mimic.Device. Only the first n bytes are written; a write of fewer than len(p) bytes without an error fails with
+	// io.ErrShortWrite.
+	Write FaultHook
+	// Read is consulted before each read from Mimic.Device or Mimic.Read. The read is limited to n bytes, and fails
+	// without consuming any data if n is 0 and err is non-nil, e.g. to simulate syscall.EINTR.
+	Read FaultHook
+}
+
+// WithFaultInjection injects failures into reads and writes of the terminal's byte stream, allowing retry and recovery
+// logic to be tested deterministically:
+//
+//	mimic.WithFaultInjection(mimic.FaultInjection{
+//		Write: mimic.FaultLimit(4),                 // short writes of at most 4 bytes
+//		Read:  mimic.FaultEvery(3, syscall.EINTR), // every third read is interrupted
+//	})
+//
+// Faults apply to the Mimic's methods and to the program's side of the terminal via Mimic.Device, but not to the tty
+// returned by Mimic.Tty and Mimic.Stdio, which programs use directly.
+func WithFaultInjection(faults FaultInjection) Option {
+	return func(opt *mimicOpt) {
+		opt.faults = faults
+	}
+}
+
+// FaultLimit is a FaultHook which limits each read or write to at most max bytes, producing short reads and writes
+func FaultLimit(max int) FaultHook {
+	return func(p []byte) (int, error) {
+		if len(p) > max {
+			return max, nil
+		}
+		return len(p), nil
+	}
+}
+
+// FaultEvery is a FaultHook which fails every nth read or write with err, without transferring any data. Other
+// operations proceed normally.
+func FaultEvery(n int, err error) FaultHook {
+	var calls int64
+	return func(p []byte) (int, error) {
+		if n > 0 && atomic.AddInt64(&calls, 1)%int64(n) == 0 {
+			return 0, err
+		}
+		return len(p), nil
+	}
+}
+
+func (f FaultInjection) enabled() bool {
+	return f.Write != nil || f.Read != nil
+}
+
+// write applies the Write hook to p, returning the portion of p to write and the error to report once it's written
+func (f FaultInjection) write(p []byte) ([]byte, error) {
+	if f.Write == nil {
+		return p, nil
+	}
+	n, err := f.Write(p)
+	if n < 0 {
+		n = 0
+	} else if n > len(p) {
+		n = len(p)
+	}
+	if err == nil && n < len(p) {
+		err = io.ErrShortWrite
+	}
+	return p[:n], err
+}
+
+// faultDevice applies a FaultInjection to the program's side of the terminal
+type faultDevice struct {
+	io.ReadWriteCloser
+	faults FaultInjection
+}
+
+func (d *faultDevice) Read(p []byte) (int, error) {
+	if d.faults.Read == nil {
+		return d.ReadWriteCloser.Read(p)
+	}
+	n, err := d.faults.Read(p)
+	if n <= 0 && err != nil {
+		return 0, err
+	}
+	if n > 0 && n < len(p) {
+		p = p[:n]
+	}
+	read, readErr := d.ReadWriteCloser.Read(p)
+	if readErr != nil {
+		return read, readErr
+	}
+	return read, err
+}
+
+func (d *faultDevice) Write(p []byte) (int, error) {
+	allowed, err := d.faults.write(p)
+	if len(allowed) == 0 && err != nil {
+		return 0, err
+	}
+	n, writeErr := d.ReadWriteCloser.Write(allowed)
+	if writeErr != nil {
+		return n, writeErr
+	}
+	return n, err
+}
@@ -0,0 +1,85 @@
+package mimic
+
+import (
+	"errors"
+	"io"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithFaultInjection_shortWrites(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithFaultInjection(FaultInjection{Write: FaultLimit(3)}), WithIdleTimeout(time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	n, err := m.WriteString("hello")
+	assert.Equal(t, 3, n)
+	assert.ErrorIs(t, err, io.ErrShortWrite)
+	n, err = m.WriteString("lo")
+	assert.Equal(t, 2, n)
+	assert.NoError(t, err)
+	input := make([]byte, 5)
+	_, err = io.ReadFull(m.Device(), input)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(input), "only the bytes reported as written should reach the program")
+
+	n, err = m.Device().Write([]byte("world"))
+	assert.Equal(t, 3, n)
+	assert.ErrorIs(t, err, io.ErrShortWrite)
+	assert.NoError(t, m.ExpectString("wor"))
+}
+
+func TestWithFaultInjection_interruptedReads(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithFaultInjection(FaultInjection{Read: FaultEvery(2, syscall.EINTR)}))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.WriteString("ab")
+	assert.NoError(t, err)
+	buf := make([]byte, 1)
+	n, err := m.Read(buf)
+	assert.Equal(t, 1, n)
+	assert.NoError(t, err)
+	n, err = m.Read(buf)
+	assert.Equal(t, 0, n)
+	assert.True(t, errors.Is(err, syscall.EINTR), "every second read should be interrupted")
+	n, err = m.Device().Read(buf)
+	assert.Equal(t, 1, n)
+	assert.NoError(t, err)
+	assert.Equal(t, "b", string(buf), "an interrupted read shouldn't consume input")
+}
+
+func TestWithFaultInjection_custom(t *testing.T) {
+	transient := errors.New("transient")
+	failed := false
+	m, err := NewMimic(WithInMemoryPty(), WithFaultInjection(FaultInjection{
+		Write: func(p []byte) (int, error) {
+			if !failed {
+				failed = true
+				return 0, transient
+			}
+			return len(p), nil
+		},
+	}))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	n, err := m.WriteString("retry")
+	assert.Equal(t, 0, n)
+	assert.ErrorIs(t, err, transient)
+	n, err = m.WriteString("retry")
+	assert.Equal(t, 5, n)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), m.Stats().BytesWritten)
+}
+
+func TestFaultLimit(t *testing.T) {
+	n, err := FaultLimit(2)([]byte("abc"))
+	assert.Equal(t, 2, n)
+	assert.NoError(t, err)
+	n, _ = FaultLimit(5)([]byte("abc"))
+	assert.Equal(t, 3, n)
+}
@@ -0,0 +1,84 @@
+package mimic
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// ContainsGlob determines if the emulated terminal's view contains one or more specified globs. Globs are a simpler
+// alternative to patterns: * matches any run of characters within a row, ? matches any single character, and \ matches
+// the character which follows it literally (e.g. \* matches an asterisk). Registered {{name}} placeholders are
+// expanded as they are in patterns, and everything else is matched as it is in ContainsString:
+//
+//	m.ContainsGlob("Welcome back, *!", "Version {{semver}} (build ????)")
+func (m *Mimic) ContainsGlob(glob ...string) bool {
+	return m.containsRegexps("ContainsGlob", glob, globRegexps(glob))
+}
+
+// ExpectGlob waits for the emulated terminal's view to contain one or more specified globs, returning the result of
+// the first glob to match. See Mimic.ExpectGlobContext.
+func (m *Mimic) ExpectGlob(glob ...string) (MatchResult, error) {
+	return m.ExpectGlobContext(context.Background(), glob...)
+}
+
+// ExpectGlobContext waits for the emulated terminal's view to contain one or more specified globs (see
+// Mimic.ContainsGlob), returning the result of the first glob to match. The expectation is abandoned with ctx's error
+// if ctx is done before a match is found.
+//
+// As with patterns, globs are evaluated as output arrives, so a trailing * matches as soon as the text preceding it is
+// written. Follow the * with a delimiter, e.g. "Saved * files.", to wait for the whole value.
+func (m *Mimic) ExpectGlobContext(ctx context.Context, glob ...string) (MatchResult, error) {
+	return m.expectRegexps(ctx, "ExpectGlob", glob, globRegexps(glob))
+}
+
+func globRegexps(globs []string) []*regexp.Regexp {
+	regexes := make([]*regexp.Regexp, 0, len(globs))
+	for _, glob := range globs {
+		// globs are quoted aside from wildcards and registered placeholders, so always compile
+		re, _ := compiledPatterns.compile(expandPattern(globPattern(glob)))
+		regexes = append(regexes, re)
+	}
+	return regexes
+}
+
+// globPattern converts glob to a regular expression, retaining registered {{name}} placeholders for expandPattern
+func globPattern(glob string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range placeholderPattern.FindAllStringSubmatchIndex(glob, -1) {
+		if _, ok := registry.lookup(glob[loc[2]:loc[3]]); !ok {
+			continue
+		}
+		b.WriteString(globSegment(glob[last:loc[0]]))
+		b.WriteString(glob[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(globSegment(glob[last:]))
+	return b.String()
+}
+
+// globSegment converts a glob without placeholders to a regular expression
+func globSegment(glob string) string {
+	var b strings.Builder
+	escaped := false
+	for _, r := range glob {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '*':
+			b.WriteString(".*")
+		case r == '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	if escaped {
+		b.WriteString(`\\`)
+	}
+	return b.String()
+}
@@ -0,0 +1,62 @@
+package mimic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobPattern(t *testing.T) {
+	tests := []struct {
+		glob string
+		want string
+	}{
+		{glob: "Name: *", want: `Name: .*`},
+		{glob: "v?.?", want: `v.\..`},
+		{glob: `5 \* 3 = 15\?`, want: `5 \* 3 = 15\?`},
+		{glob: `C:\\Users`, want: `C:\\Users`},
+		{glob: `trailing\`, want: `trailing\\`},
+		{glob: "Version {{semver}} (*)", want: `Version {{semver}} \(.*\)`},
+		{glob: "{{unregistered}}", want: `\{\{unregistered\}\}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.glob, func(t *testing.T) {
+			assert.Equal(t, tt.want, globPattern(tt.glob))
+		})
+	}
+}
+
+func TestMimic_ContainsGlob(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(50 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Tty().WriteString("Welcome back, Jim!\r\nmimic v1.2.3 (build 0042)\r\n[*] done")
+	assert.NoError(t, err)
+
+	assert.True(t, m.ContainsGlob("Welcome back, *!"))
+	assert.True(t, m.ContainsGlob("mimic {{semver}} (build ????)", `[\*] done`))
+	assert.False(t, m.ContainsGlob("Welcome * done"), "* shouldn't match across rows")
+	assert.False(t, m.ContainsGlob("build ???)"))
+}
+
+func TestMimic_ExpectGlob(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(50 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = m.Tty().WriteString("Saved 12 files.\r\n")
+	}()
+
+	result, err := m.ExpectGlob("Saved * files.", "Failed *")
+	assert.NoError(t, err)
+	assert.Equal(t, "Saved * files.", result.Pattern)
+	assert.Equal(t, "Saved 12 files.", result.Text)
+
+	_, err = m.ExpectGlob("Deleted * files.")
+	assert.ErrorIs(t, err, ErrExpectTimeout)
+	assert.ErrorContains(t, err, "Deleted * files.", "failures should report the glob")
+}
@@ -5,13 +5,25 @@ go 1.18
 require (
 	github.com/Netflix/go-expect v0.0.0-20220104043353-73e0943537d2
 	github.com/creack/pty v1.1.18
-	github.com/hinshun/vt10x v0.0.0-20220301184237-5011da428d02
 	github.com/jimschubert/stripansi v0.0.0-20221113221937-05f1bd5504ce
+	github.com/onsi/ginkgo/v2 v2.9.2
+	github.com/onsi/gomega v1.27.6
+	github.com/pmezard/go-difflib v1.0.0
+	github.com/rivo/uniseg v0.4.4
 	github.com/stretchr/testify v1.8.1
+	golang.org/x/crypto v0.7.0
+	golang.org/x/term v0.6.0
+	golang.org/x/text v0.8.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/go-logr/logr v1.2.3 // indirect
+	github.com/go-task/slim-sprig v0.0.0-20230315185526-52ccab3ef572 // indirect
+	github.com/google/go-cmp v0.5.9 // indirect
+	github.com/google/pprof v0.0.0-20210407192527-94a9f03dee38 // indirect
+	golang.org/x/net v0.8.0 // indirect
+	golang.org/x/sys v0.6.0 // indirect
+	golang.org/x/tools v0.7.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
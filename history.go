@@ -0,0 +1,55 @@
+package mimic
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/jimschubert/stripansi"
+)
+
+// WithHistory retains every byte of output read from the terminal, retrievable via Mimic.History. The view only holds
+// the current screen, so this allows asserting on output which has since scrolled away or been overwritten. History
+// grows with the output for the lifetime of the Mimic.
+func WithHistory() Option {
+	return func(opt *mimicOpt) {
+		opt.history = true
+	}
+}
+
+// history retains output; a nil history retains nothing
+type history struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (h *history) Write(p []byte) (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.buf.Write(p)
+}
+
+func (h *history) bytes() []byte {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]byte(nil), h.buf.Bytes()...)
+}
+
+// History returns all output read from the terminal so far, including ANSI escape sequences, after flushing pending
+// output. It returns nil unless the Mimic was created with WithHistory.
+func (m *Mimic) History() []byte {
+	if m.history == nil {
+		return nil
+	}
+	if err := m.Flush(); err != nil {
+		m.debug("History failed to flush", "error", err)
+	}
+	return m.history.bytes()
+}
+
+// HistoryString returns all output read from the terminal so far as History does, stripped of ANSI escape sequences
+func (m *Mimic) HistoryString() string {
+	return stripansi.String(string(m.History()))
+}
@@ -0,0 +1,35 @@
+package mimic
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithHistory(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithSize(3, 20), WithHistory(), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	for i := 1; i <= 5; i++ {
+		_, err = fmt.Fprintf(m.Device(), "\x1b[1mline %d\x1b[0m\r\n", i)
+		assert.NoError(t, err)
+	}
+
+	assert.False(t, m.ContainsString("line 1"), "the first line should have scrolled out of the view")
+	assert.Contains(t, m.HistoryString(), "line 1\r\nline 2")
+	assert.Contains(t, string(m.History()), "\x1b[1mline 5\x1b[0m", "history should include escape sequences")
+}
+
+func TestMimic_History_disabled(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty())
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Device().Write([]byte("output"))
+	assert.NoError(t, err)
+	assert.Nil(t, m.History())
+	assert.Empty(t, m.HistoryString())
+}
@@ -0,0 +1,79 @@
+package mimic
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/jimschubert/mimic/internal/vt10x"
+)
+
+// oscHyperlink prefixes the payload of an OSC 8 hyperlink sequence, ESC ] 8 ; params ; uri ST
+var oscHyperlink = []byte("8;")
+
+// Hyperlink is a clickable link written via an OSC 8 sequence. See Mimic.Hyperlinks.
+type Hyperlink struct {
+	// URI is the link's target
+	URI string
+	// Text is the visible text of the link, i.e. the printable output between the sequences opening and closing it
+	Text string
+	// Row and Column are the zero-based position of the link's first character at the time it was written
+	Row    int
+	Column int
+}
+
+// hyperlinks extracts OSC 8 hyperlinks from output
+type hyperlinks struct {
+	mu       sync.Mutex
+	terminal vt10x.Terminal
+	parser   controlParser
+	links    []Hyperlink
+	open     *Hyperlink
+	text     []byte
+}
+
+func (h *hyperlinks) Write(p []byte) (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, b := range p {
+		before := h.parser.state
+		ctl, ok := h.parser.feed(b)
+		switch {
+		case ok && ctl.is(']', oscHyperlink):
+			h.link(string(ctl.payload))
+		case h.open != nil && h.parser.text(before, b):
+			h.text = append(h.text, b)
+		}
+	}
+	return len(p), nil
+}
+
+// link closes the open link, if any, and opens a link to the URI of the payload, if it has one
+func (h *hyperlinks) link(payload string) {
+	if h.open != nil {
+		h.open.Text = string(h.text)
+		h.links = append(h.links, *h.open)
+		h.open = nil
+	}
+	parts := strings.SplitN(payload, ";", 3)
+	if len(parts) < 3 || parts[2] == "" {
+		return
+	}
+	h.terminal.Lock()
+	cursor := h.terminal.Cursor()
+	h.terminal.Unlock()
+	h.open = &Hyperlink{URI: parts[2], Row: cursor.Y, Column: cursor.X}
+	h.text = h.text[:0]
+}
+
+func (h *hyperlinks) all() []Hyperlink {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Hyperlink(nil), h.links...)
+}
+
+// Hyperlinks returns the OSC 8 hyperlinks written so far, in the order they were closed, after flushing pending output.
+// A link which hasn't been closed yet isn't included.
+func (m *Mimic) Hyperlinks() []Hyperlink {
+	_ = m.Flush()
+	return m.hyperlinks.all()
+}
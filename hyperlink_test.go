@@ -0,0 +1,26 @@
+package mimic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_Hyperlinks(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithSize(5, 40), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Device().Write([]byte("Docs: \x1b]8;id=1;https://example.com/docs\x1b\\\x1b[1mhere\x1b[0m\x1b]8;;\x1b\\\r\n" +
+		"\x1b]8;;https://one.example\aone\x1b]8;;https://two.example\atwo\x1b]8;;\a\r\n" +
+		"\x1b]8;;https://open.example\aunclosed"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Hyperlink{
+		{URI: "https://example.com/docs", Text: "here", Row: 0, Column: 6},
+		{URI: "https://one.example", Text: "one", Row: 1, Column: 0},
+		{URI: "https://two.example", Text: "two", Row: 1, Column: 3},
+	}, m.Hyperlinks(), "styling shouldn't be included in text, and opening a link should close the previous one")
+	assert.True(t, m.ContainsString("Docs: here", "onetwo"), "links should be rendered as their text")
+}
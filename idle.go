@@ -0,0 +1,202 @@
+package mimic
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/jimschubert/mimic/internal"
+	"github.com/jimschubert/mimic/internal/expect"
+)
+
+// IdleStrategy determines how Mimic.WaitForIdle decides that the emulated terminal is idle.
+type IdleStrategy int
+
+const (
+	// CursorStable considers the terminal idle once the cursor hasn't moved for the idle duration. This is the default.
+	CursorStable IdleStrategy = iota
+	// OutputActivity considers the terminal idle once no output has been written for the idle duration. Unlike
+	// CursorStable, this accounts for applications which redraw in place without moving the cursor
+	// (e.g. SGR-only updates or overwriting the same cell).
+	OutputActivity
+	// ContentStable considers the terminal idle once the contents of the view haven't changed for the idle duration.
+	// Unlike CursorStable, this accounts for full-screen redraws which end with the cursor where it started. Pending
+	// output is read into the view while waiting.
+	ContentStable
+)
+
+// WithIdleStrategy defines how mimic determines the terminal is idle via Mimic.WaitForIdle.
+func WithIdleStrategy(strategy IdleStrategy) Option {
+	return func(opt *mimicOpt) {
+		opt.idleStrategy = strategy
+	}
+}
+
+// WithIdlePollInterval defines how often Mimic.WaitForIdle checks for pending output while it reads output into the
+// view (see ContentStable). While no output arrives, the interval backs off exponentially to the lesser of the idle
+// duration (see WithIdleDuration) and 100ms, returning to interval once output arrives. This keeps large, parallel
+// suites from spinning while they wait. Intervals which aren't positive are ignored.
+func WithIdlePollInterval(interval time.Duration) Option {
+	return func(opt *mimicOpt) {
+		if interval > 0 {
+			opt.idlePoll = interval
+		}
+	}
+}
+
+// waitForOutputIdle reads output into the terminal view until no bytes have arrived for the idle duration.
+func (m *Mimic) waitForOutputIdle(ctx context.Context) error {
+	timeoutContext, cancel := context.WithTimeout(ctx, m.maxIdleWait)
+	defer cancel()
+
+	m.reading.Lock()
+	defer m.reading.Unlock()
+
+	// each read resets the read deadline, so this only completes once output has been quiet for idleDuration
+	buf, err := m.expectConsole(expect.WithTimeout(m.idleDuration), func(opts *expect.ExpectOpts) error {
+		opts.Matchers = append(opts.Matchers, &internal.AnyMatcher{Matchers: []expect.Matcher{
+			&internal.EOFMatcher{},
+			&internal.FlushMatcher{},
+			&internal.ContextMatcher{Ctx: timeoutContext},
+		}})
+		return nil
+	})
+	m.strict.read(buf)
+	if err != nil {
+		return wrapConsoleError(err)
+	}
+
+	return timeoutContext.Err()
+}
+
+// idleWatcher is notified via changed when the value of state differs after output is written to the view
+type idleWatcher struct {
+	state   func() uint64
+	last    uint64
+	changed func()
+}
+
+// idleWatchers notifies Mimic.WaitForIdle of changes to the view
+type idleWatchers struct {
+	mu       sync.Mutex
+	watchers map[*idleWatcher]struct{}
+}
+
+// watch invokes changed on each write which changes the value of state, until the returned stop function is invoked
+func (w *idleWatchers) watch(state func() uint64, changed func()) (stop func()) {
+	watcher := &idleWatcher{state: state, last: state(), changed: changed}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.watchers == nil {
+		w.watchers = make(map[*idleWatcher]struct{})
+	}
+	w.watchers[watcher] = struct{}{}
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.watchers, watcher)
+	}
+}
+
+func (w *idleWatchers) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for watcher := range w.watchers {
+		if current := watcher.state(); current != watcher.last {
+			watcher.last = current
+			watcher.changed()
+		}
+	}
+	return len(p), nil
+}
+
+// waitForStableState waits until state has held the same value for the idle duration. Rather than sampling state,
+// each write to the view which changes it restarts the idle timer. If read is true, pending output is read into the
+// view while waiting; otherwise, the view changes only as other operations (e.g. expectations) read output.
+func (m *Mimic) waitForStableState(ctx context.Context, state func() uint64, read bool) error {
+	timeoutContext, cancel := context.WithTimeout(ctx, m.maxIdleWait)
+	defer cancel()
+
+	idleContext, idle := context.WithCancel(timeoutContext)
+	defer idle()
+	timer := time.AfterFunc(m.idleDuration, idle)
+	defer timer.Stop()
+	stop := m.idle.watch(state, func() {
+		timer.Reset(m.idleDuration)
+	})
+	defer stop()
+
+	if read {
+		if err := m.readUntilDone(idleContext); err != nil {
+			return err
+		}
+	}
+
+	<-idleContext.Done()
+	// we didn't stabilize if the timeout elapsed first :(
+	return timeoutContext.Err()
+}
+
+// maxIdleReadSlice bounds how long a read by readUntilDone may block once output has stopped arriving
+const maxIdleReadSlice = 100 * time.Millisecond
+
+// readUntilDone reads output into the view until ctx is done or the end of the stream is reached. Reads are performed
+// in slices so that ctx is observed while no output is arriving: a slice begins at the idle poll interval (see
+// WithIdlePollInterval) and doubles with each read in which nothing arrives (see nextReadSlice), so that waits across
+// many parallel tests don't spin.
+func (m *Mimic) readUntilDone(ctx context.Context) error {
+	m.reading.Lock()
+	defer m.reading.Unlock()
+
+	slice := m.idlePoll
+	for ctx.Err() == nil {
+		eof := &internal.CarryMatcher{Matcher: internal.EOFMatcher{}}
+		buf, err := m.expectConsole(expect.WithTimeout(slice), func(opts *expect.ExpectOpts) error {
+			opts.Matchers = append(opts.Matchers, eof, &internal.FlushMatcher{}, &internal.ContextMatcher{Ctx: ctx})
+			return nil
+		})
+		m.strict.read(buf)
+		if err != nil {
+			return wrapConsoleError(err)
+		}
+		if eof.Matched {
+			// the view won't change again
+			return nil
+		}
+		slice = nextReadSlice(slice, buf != "", m.idlePoll, m.idleDuration)
+	}
+	return nil
+}
+
+// nextReadSlice is the duration of the read following one of slice: poll once output arrives, otherwise double slice,
+// up to the lesser of idle and maxIdleReadSlice, so that idleness is still observed within about idle
+func nextReadSlice(slice time.Duration, arrived bool, poll, idle time.Duration) time.Duration {
+	if arrived {
+		return poll
+	}
+	limit := maxIdleReadSlice
+	if idle < limit {
+		limit = idle
+	}
+	if slice *= 2; slice > limit {
+		slice = limit
+	}
+	if slice < poll {
+		return poll
+	}
+	return slice
+}
+
+// cursorState identifies the position of the cursor
+func (m *Mimic) cursorState() uint64 {
+	c := m.terminalCursor()
+	return uint64(c.Y)<<32 | uint64(uint32(c.X))
+}
+
+// contentState is a hash of the view's contents
+func (m *Mimic) contentState() uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(m.terminal.String()))
+	return h.Sum64()
+}
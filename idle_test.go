@@ -0,0 +1,183 @@
+package mimic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_WaitForIdle_OutputActivity(t *testing.T) {
+	m, err := NewMimic(
+		WithIdleStrategy(OutputActivity),
+		WithIdleDuration(50*time.Millisecond),
+		WithIdleTimeout(2*time.Second),
+	)
+	assert.NoError(t, err)
+	defer m.Close()
+
+	writeDuration := 200 * time.Millisecond
+	go func() {
+		// SGR-only updates never move the cursor
+		deadline := time.Now().Add(writeDuration)
+		for time.Now().Before(deadline) {
+			_, _ = m.Tty().WriteString("\x1b[31m\x1b[0m")
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	started := time.Now()
+	assert.NoError(t, m.WaitForIdle(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(started), writeDuration, "terminal should not be idle while output is written")
+}
+
+func TestMimic_WaitForIdle_OutputActivity_timeout(t *testing.T) {
+	m, err := NewMimic(
+		WithIdleStrategy(OutputActivity),
+		WithIdleDuration(50*time.Millisecond),
+		WithIdleTimeout(100*time.Millisecond),
+	)
+	assert.NoError(t, err)
+	defer m.Close()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				_, _ = m.Tty().WriteString(".")
+				time.Sleep(5 * time.Millisecond)
+			}
+		}
+	}()
+
+	assert.ErrorIs(t, m.WaitForIdle(context.Background()), context.DeadlineExceeded)
+}
+
+func TestMimic_WaitForIdle_ContentStable(t *testing.T) {
+	m, err := NewMimic(
+		WithSize(5, 20),
+		WithIdleStrategy(ContentStable),
+		WithIdleDuration(100*time.Millisecond),
+		WithIdleTimeout(2*time.Second),
+	)
+	assert.NoError(t, err)
+	defer m.Close()
+
+	writeDuration := 300 * time.Millisecond
+	go func() {
+		// each frame redraws the screen, leaving the cursor where the previous frame left it
+		deadline := time.Now().Add(writeDuration)
+		for frame := 0; time.Now().Before(deadline); frame++ {
+			_, _ = m.Tty().WriteString(fmt.Sprintf("\x1b[Hframe %d\x1b[2;1H", frame))
+			time.Sleep(40 * time.Millisecond)
+		}
+	}()
+
+	started := time.Now()
+	assert.NoError(t, m.WaitForIdle(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(started), writeDuration, "terminal should not be idle while the screen is redrawn")
+	assert.Equal(t, 1, m.terminalCursor().Y)
+}
+
+func TestMimic_WaitForIdle_ContentStable_timeout(t *testing.T) {
+	m, err := NewMimic(
+		WithIdleStrategy(ContentStable),
+		WithIdleDuration(100*time.Millisecond),
+		WithIdleTimeout(200*time.Millisecond),
+	)
+	assert.NoError(t, err)
+	defer m.Close()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for frame := 0; ; frame++ {
+			select {
+			case <-done:
+				return
+			default:
+				_, _ = m.Tty().WriteString(fmt.Sprintf("\x1b[H%d", frame))
+				time.Sleep(5 * time.Millisecond)
+			}
+		}
+	}()
+
+	assert.ErrorIs(t, m.WaitForIdle(context.Background()), context.DeadlineExceeded)
+}
+
+func TestMimic_WaitForIdle_CursorStable(t *testing.T) {
+	m, err := NewMimic(
+		WithSize(5, 20),
+		WithIdleDuration(100*time.Millisecond),
+		WithIdleTimeout(2*time.Second),
+	)
+	assert.NoError(t, err)
+	defer m.Close()
+
+	writeDuration := 300 * time.Millisecond
+	go func() {
+		// expectations read output into the view, moving the cursor
+		deadline := time.Now().Add(writeDuration)
+		for time.Now().Before(deadline) {
+			_, _ = m.Tty().WriteString(".")
+			_ = m.ExpectString(".")
+			time.Sleep(20 * time.Millisecond)
+		}
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	started := time.Now()
+	assert.NoError(t, m.WaitForIdle(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(started), writeDuration-20*time.Millisecond, "terminal should not be idle while the cursor moves")
+}
+
+func TestMimic_WaitForIdle_CursorStable_noOutput(t *testing.T) {
+	m, err := NewMimic(WithIdleDuration(20*time.Millisecond), WithIdleTimeout(150*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	started := time.Now()
+	assert.NoError(t, m.WaitForIdle(context.Background()), "a cursor which never moves is idle")
+	assert.Less(t, time.Since(started), 150*time.Millisecond)
+}
+
+func TestWithIdlePollInterval(t *testing.T) {
+	m, err := NewMimic(WithIdlePollInterval(time.Millisecond), WithIdlePollInterval(0))
+	assert.NoError(t, err)
+	defer m.Close()
+	assert.Equal(t, time.Millisecond, m.idlePoll, "non-positive intervals should be ignored")
+
+	m, err = NewMimic()
+	assert.NoError(t, err)
+	defer m.Close()
+	assert.Equal(t, expectPollInterval, m.idlePoll)
+}
+
+func TestNextReadSlice(t *testing.T) {
+	tests := []struct {
+		name    string
+		slice   time.Duration
+		arrived bool
+		poll    time.Duration
+		idle    time.Duration
+		want    time.Duration
+	}{
+		{"doubles while nothing arrives", expectPollInterval, false, expectPollInterval, time.Second, 2 * expectPollInterval},
+		{"resets once output arrives", maxIdleReadSlice, true, expectPollInterval, time.Second, expectPollInterval},
+		{"resets to the poll interval", maxIdleReadSlice, true, time.Millisecond, time.Second, time.Millisecond},
+		{"is bounded by maxIdleReadSlice", maxIdleReadSlice, false, expectPollInterval, time.Second, maxIdleReadSlice},
+		{"is bounded by the idle duration", 32 * time.Millisecond, false, expectPollInterval, 50 * time.Millisecond, 50 * time.Millisecond},
+		{"never falls below the poll interval", expectPollInterval, false, expectPollInterval, time.Millisecond, expectPollInterval},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nextReadSlice(tt.slice, tt.arrived, tt.poll, tt.idle))
+		})
+	}
+}
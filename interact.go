@@ -0,0 +1,131 @@
+package mimic
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// DefaultDetachKey ends Mimic.Interact: Ctrl+] (GS, 0x1d), as used by telnet
+const DefaultDetachKey byte = 0x1d
+
+// WithDetachKey sets the key which ends Mimic.Interact, e.g. 0x01 for Ctrl+A. The key is not forwarded to the terminal.
+func WithDetachKey(key byte) Option {
+	return func(opt *mimicOpt) {
+		opt.detachKey = key
+	}
+}
+
+// passthrough forwards output to the user's terminal during Mimic.Interact
+type passthrough struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (p *passthrough) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.w != nil {
+		_, _ = p.w.Write(b)
+	}
+	return len(b), nil
+}
+
+func (p *passthrough) set(w io.Writer) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.w = w
+}
+
+// Interact connects the user's terminal to the emulated terminal, handing control of the program under test to the
+// user until they press the detach key (Ctrl+] by default, see WithDetachKey), like expect's interact. This allows
+// taking over a scripted session manually, e.g. to debug a failing expectation:
+//
+//	if err := console.ExpectString("Continue?"); err != nil {
+//		_ = console.Interact(context.Background())
+//	}
+//
+// The user's terminal is os.Stdin and os.Stdout or, where os.Stdin isn't a terminal (as under go test, which doesn't
+// connect tests to its standard input), the controlling terminal /dev/tty. The user's terminal is put in raw mode
+// until Interact returns. While interacting, keystrokes are written to the emulated terminal, and output is rendered
+// into the view as well as to the user's terminal. Interact returns nil once the detach key is pressed or the
+// program's output ends, and ctx's error if ctx is done first. Expectations may continue against the view afterward.
+//
+// Reads of os.Stdin can't be interrupted, so a read which is pending when Interact returns discards the next chunk of
+// input (typically a single keystroke).
+func (m *Mimic) Interact(ctx context.Context) error {
+	in, out := os.Stdin, os.Stdout
+	if !term.IsTerminal(int(in.Fd())) {
+		if tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0); err == nil {
+			defer tty.Close()
+			in, out = tty, tty
+		}
+	}
+	if fd := int(in.Fd()); term.IsTerminal(fd) {
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = term.Restore(fd, state) }()
+	}
+	return m.interact(ctx, in, out)
+}
+
+// interact forwards input from in to the terminal, and output from the terminal to out, until the detach key is read
+// from in, the output ends, or ctx is done
+func (m *Mimic) interact(ctx context.Context, in io.Reader, out io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	m.passthrough.set(out)
+	defer m.passthrough.set(nil)
+
+	detached := make(chan struct{})
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, err := in.Read(buf)
+			if ctx.Err() != nil {
+				return
+			}
+			chunk := buf[:n]
+			i := bytes.IndexByte(chunk, m.detachKey)
+			if i >= 0 {
+				chunk = chunk[:i]
+			}
+			if len(chunk) > 0 {
+				if _, writeErr := m.Write(chunk); writeErr != nil {
+					m.debug("interact failed to write input", "error", writeErr)
+				}
+			}
+			if i >= 0 {
+				close(detached)
+				cancel()
+				return
+			}
+			if err != nil {
+				// the user's input ended, but the program's output may continue
+				return
+			}
+		}
+	}()
+
+	err := m.readUntilDone(ctx)
+	if err != nil {
+		return err
+	}
+	select {
+	case <-detached:
+		return nil
+	default:
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	// the program's output ended
+	return nil
+}
@@ -0,0 +1,66 @@
+package mimic
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_interact(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithSize(5, 20), WithDetachKey(0x01))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	userIn, keystrokes := io.Pipe()
+	screen, userOut := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- m.interact(context.Background(), userIn, userOut)
+	}()
+
+	_, err = keystrokes.Write([]byte("ping"))
+	assert.NoError(t, err)
+	input := make([]byte, 4)
+	_, err = io.ReadFull(m.Device(), input)
+	assert.NoError(t, err)
+	assert.Equal(t, "ping", string(input), "keystrokes should be written to the terminal")
+
+	_, err = m.Device().Write([]byte("pong"))
+	assert.NoError(t, err)
+	output, err := bufio.NewReader(screen).Peek(4)
+	assert.NoError(t, err)
+	assert.Equal(t, "pong", string(output), "output should be forwarded to the user")
+
+	_, err = keystrokes.Write([]byte("!\x01ignored"))
+	assert.NoError(t, err)
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("interact should end on the detach key")
+	}
+	assert.True(t, m.ContainsString("pong"), "output should be rendered into the view")
+
+	// input before the detach key is written, and the remainder is discarded
+	_, err = io.ReadFull(m.Device(), input[:1])
+	assert.NoError(t, err)
+	assert.Equal(t, "!", string(input[:1]))
+	_, err = m.Device().Write([]byte("after"))
+	assert.NoError(t, err)
+	assert.NoError(t, m.ExpectString("after"), "expectations should continue after detaching")
+}
+
+func TestMimic_interact_canceled(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty())
+	assert.NoError(t, err)
+	defer m.Close()
+
+	userIn, _ := io.Pipe()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, m.interact(ctx, userIn, io.Discard), context.DeadlineExceeded)
+}
@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiRed     = "\x1b[31m"
+	ansiGreen   = "\x1b[32m"
+	ansiCyan    = "\x1b[36m"
+	diffContext = 1
+)
+
+// UnifiedDiff is a line-level unified diff between expected and actual, labelled with expectedName and actualName.
+// When color is true, file headers are bold, hunk headers are cyan, removed lines are red and added lines are green.
+// An empty string is returned if expected and actual are equal.
+func UnifiedDiff(expected, actual, expectedName, actualName string, color bool) string {
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(expected),
+		B:        difflib.SplitLines(actual),
+		FromFile: expectedName,
+		ToFile:   actualName,
+		Context:  diffContext,
+	})
+	diff = strings.TrimSuffix(diff, "\n")
+	if !color || diff == "" {
+		return diff
+	}
+
+	lines := strings.Split(diff, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "--- "), strings.HasPrefix(line, "+++ "):
+			lines[i] = ansiBold + line + ansiReset
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = ansiCyan + line + ansiReset
+		case strings.HasPrefix(line, "-"):
+			lines[i] = ansiRed + line + ansiReset
+		case strings.HasPrefix(line, "+"):
+			lines[i] = ansiGreen + line + ansiReset
+		}
+	}
+	return strings.Join(lines, "\n")
+}
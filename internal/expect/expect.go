@@ -0,0 +1,29 @@
+//go:build !js
+
+// Package expect is the subset of github.com/Netflix/go-expect used by mimic. go-expect depends upon ptys, so it's
+// aliased here on platforms which have them, and its matching types are implemented without a console under js.
+package expect
+
+import (
+	"github.com/Netflix/go-expect"
+)
+
+type (
+	Console         = expect.Console
+	ConsoleOpt      = expect.ConsoleOpt
+	ExpectOpt       = expect.ExpectOpt
+	ExpectOpts      = expect.ExpectOpts
+	Matcher         = expect.Matcher
+	CallbackMatcher = expect.CallbackMatcher
+)
+
+var (
+	NewConsole  = expect.NewConsole
+	WithStdin   = expect.WithStdin
+	WithStdout  = expect.WithStdout
+	WithCloser  = expect.WithCloser
+	WithLogger  = expect.WithLogger
+	WithTimeout = expect.WithTimeout
+	EOF         = expect.EOF
+	PTSClosed   = expect.PTSClosed
+)
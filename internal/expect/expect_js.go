@@ -0,0 +1,94 @@
+//go:build js
+
+// Package expect is the subset of github.com/Netflix/go-expect used by mimic. go-expect depends upon ptys, so it's
+// aliased here on platforms which have them, and its matching types are implemented without a console under js.
+package expect
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"syscall"
+	"time"
+)
+
+// Console is never constructed under js, which has no ptys
+type Console struct{}
+
+// ExpectOpt configures an expectation
+type ExpectOpt func(*ExpectOpts) error
+
+// ExpectOpts are the conditions of an expectation
+type ExpectOpts struct {
+	Matchers    []Matcher
+	ReadTimeout *time.Duration
+}
+
+// Match returns the first of the options' matchers which matches v, or nil if none do
+func (eo ExpectOpts) Match(v interface{}) Matcher {
+	for _, matcher := range eo.Matchers {
+		if matcher.Match(v) {
+			return matcher
+		}
+	}
+	return nil
+}
+
+// Matcher is evaluated with the output read so far, as a *bytes.Buffer, or with the error which ended a read
+type Matcher interface {
+	Match(v interface{}) bool
+	Criteria() interface{}
+}
+
+// CallbackMatcher is a Matcher whose Callback is invoked with the output read once it matches
+type CallbackMatcher interface {
+	Callback(buf *bytes.Buffer) error
+}
+
+// WithTimeout limits the time each read waits for output
+func WithTimeout(timeout time.Duration) ExpectOpt {
+	return func(opts *ExpectOpts) error {
+		opts.ReadTimeout = &timeout
+		return nil
+	}
+}
+
+// EOF matches the end of the stream
+func EOF(opts *ExpectOpts) error {
+	opts.Matchers = append(opts.Matchers, &errorMatcher{err: io.EOF})
+	return nil
+}
+
+// PTSClosed matches the error reported by reads of a pty once its tty is closed
+func PTSClosed(opts *ExpectOpts) error {
+	opts.Matchers = append(opts.Matchers, &pathErrorMatcher{
+		pathError: os.PathError{Op: "read", Path: "/dev/ptmx", Err: syscall.Errno(0x5)},
+	})
+	return nil
+}
+
+type errorMatcher struct {
+	err error
+}
+
+func (em *errorMatcher) Match(v interface{}) bool {
+	err, ok := v.(error)
+	return ok && err == em.err
+}
+
+func (em *errorMatcher) Criteria() interface{} {
+	return em.err
+}
+
+type pathErrorMatcher struct {
+	pathError os.PathError
+}
+
+func (em *pathErrorMatcher) Match(v interface{}) bool {
+	pathError, ok := v.(*os.PathError)
+	return ok && *pathError == em.pathError
+}
+
+func (em *pathErrorMatcher) Criteria() interface{} {
+	return em.pathError
+}
@@ -2,12 +2,13 @@ package internal
 
 import (
 	"bytes"
+	"context"
 	"io"
-	"io/fs"
+	"os"
 	"regexp"
 	"strings"
 
-	"github.com/Netflix/go-expect"
+	"github.com/jimschubert/mimic/internal/expect"
 	"github.com/jimschubert/stripansi"
 )
 
@@ -16,15 +17,9 @@ import (
 type FlushMatcher struct{}
 
 func (f FlushMatcher) Match(v interface{}) bool {
-	if err, ok := v.(error); ok {
-		if pathError, isReadErr := err.(*fs.PathError); isReadErr {
-			if pathError.Op == "read" && pathError.Err.Error() == "i/o timeout" {
-				// we've flushed as much as we can and hit a read timeout
-				return true
-			}
-		}
-	}
-	return false
+	// we've flushed as much as we can and hit a read timeout
+	err, ok := v.(error)
+	return ok && os.IsTimeout(err)
 }
 
 func (f FlushMatcher) Criteria() interface{} {
@@ -47,6 +42,76 @@ func (e EOFMatcher) Criteria() interface{} {
 	return io.EOF
 }
 
+// ContextMatcher matches once its context is done, allowing an expectation to be abandoned on cancellation or deadline.
+// Note that matchers are only evaluated as runes are read or a read fails, so cancellation is observed on the next read.
+type ContextMatcher struct {
+	Ctx context.Context
+}
+
+func (c ContextMatcher) Match(_ interface{}) bool {
+	return c.Ctx.Err() != nil
+}
+
+func (c ContextMatcher) Criteria() interface{} {
+	return c.Ctx
+}
+
+// TimeoutMatcher matches a read timeout, recording the timeout error in Err
+type TimeoutMatcher struct {
+	Err error
+}
+
+func (t *TimeoutMatcher) Match(v interface{}) bool {
+	if err, ok := v.(error); ok && os.IsTimeout(err) {
+		t.Err = err
+		return true
+	}
+	return false
+}
+
+func (t *TimeoutMatcher) Criteria() interface{} {
+	return os.ErrDeadlineExceeded
+}
+
+// CarryMatcher evaluates Matcher against Carried content followed by the buffer being matched. This allows a single
+// logical expectation to span multiple invocations of Console.Expect, each of which begins with an empty buffer.
+// Matched records whether Matcher has matched.
+type CarryMatcher struct {
+	Carried *RingBuffer
+	Matcher expect.Matcher
+	Matched bool
+}
+
+func (c *CarryMatcher) Match(v interface{}) bool {
+	if buf, ok := v.(*bytes.Buffer); ok && c.Carried != nil && c.Carried.Len() > 0 {
+		combined := bytes.NewBuffer(make([]byte, 0, c.Carried.Len()+buf.Len()))
+		combined.Write(c.Carried.Bytes())
+		combined.Write(buf.Bytes())
+		v = combined
+	}
+	if c.Matcher.Match(v) {
+		c.Matched = true
+		return true
+	}
+	return false
+}
+
+func (c *CarryMatcher) Criteria() interface{} {
+	return c.Matcher.Criteria()
+}
+
+// OutputMatcher matches as soon as any output has been read
+type OutputMatcher struct{}
+
+func (o OutputMatcher) Match(v interface{}) bool {
+	buf, ok := v.(*bytes.Buffer)
+	return ok && buf.Len() > 0
+}
+
+func (o OutputMatcher) Criteria() interface{} {
+	return struct{}{}
+}
+
 // AnyMatcher collects multiple matchers to be evaluated as a single unit via Console.Expect
 type AnyMatcher struct {
 	Matchers []expect.Matcher
@@ -74,6 +139,9 @@ func (a AnyMatcher) Criteria() interface{} {
 // but differs in that it also escapes ANSI in the buffer to match against plain text
 type PlainStringMatcher struct {
 	S string
+	// Contains optionally overrides how S is found within the buffer (e.g. for locale-aware comparisons).
+	// Defaults to strings.Contains.
+	Contains func(s, substr string) bool
 }
 
 func (w PlainStringMatcher) Match(v interface{}) bool {
@@ -81,7 +149,11 @@ func (w PlainStringMatcher) Match(v interface{}) bool {
 	if !ok {
 		return false
 	}
-	if strings.Contains(stripansi.String(buf.String()), w.S) {
+	contains := w.Contains
+	if contains == nil {
+		contains = strings.Contains
+	}
+	if contains(stripansi.String(buf.String()), w.S) {
 		return true
 	}
 	return false
@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Pipe is an in-memory, buffered pipe. Unlike io.Pipe, writes never block on readers, and reads support deadlines
+// which fail with os.ErrDeadlineExceeded, as reads of an *os.File do. Once closed, reads return buffered data followed
+// by io.EOF.
+type Pipe struct {
+	mu       sync.Mutex
+	buf      bytes.Buffer
+	closed   bool
+	deadline time.Time
+	// signal is closed, and replaced, whenever data is written or the pipe is closed
+	signal chan struct{}
+}
+
+// NewPipe creates an empty Pipe
+func NewPipe() *Pipe {
+	return &Pipe{signal: make(chan struct{})}
+}
+
+// notify wakes blocked readers; the caller must hold mu
+func (p *Pipe) notify() {
+	close(p.signal)
+	p.signal = make(chan struct{})
+}
+
+func (p *Pipe) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return 0, io.ErrClosedPipe
+	}
+	n, _ := p.buf.Write(b)
+	p.notify()
+	return n, nil
+}
+
+func (p *Pipe) Read(b []byte) (int, error) {
+	for {
+		p.mu.Lock()
+		if p.buf.Len() > 0 {
+			n, _ := p.buf.Read(b)
+			p.mu.Unlock()
+			return n, nil
+		}
+		if p.closed {
+			p.mu.Unlock()
+			return 0, io.EOF
+		}
+		deadline := p.deadline
+		if !deadline.IsZero() && !time.Now().Before(deadline) {
+			p.mu.Unlock()
+			return 0, os.ErrDeadlineExceeded
+		}
+		signal := p.signal
+		p.mu.Unlock()
+
+		if deadline.IsZero() {
+			<-signal
+			continue
+		}
+		timer := time.NewTimer(time.Until(deadline))
+		select {
+		case <-signal:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// SetReadDeadline sets the time after which blocked and future reads fail with os.ErrDeadlineExceeded. A zero value
+// disables the deadline.
+func (p *Pipe) SetReadDeadline(t time.Time) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.deadline = t
+	p.notify()
+	return nil
+}
+
+// Close closes the pipe for writing. Data which was already written remains readable.
+func (p *Pipe) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.closed {
+		p.closed = true
+		p.notify()
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package internal
+
+// RingBuffer retains the most recent bytes written to it, up to its capacity; older bytes are overwritten. A
+// RingBuffer with a capacity of zero or less retains everything, as a bytes.Buffer does.
+type RingBuffer struct {
+	data     []byte
+	capacity int
+	// start is the index of the oldest byte once data is full
+	start int
+	// dropped is the number of bytes overwritten
+	dropped int64
+}
+
+// NewRingBuffer creates an empty RingBuffer retaining at most capacity bytes
+func NewRingBuffer(capacity int) *RingBuffer {
+	return &RingBuffer{capacity: capacity}
+}
+
+func (r *RingBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if r.capacity <= 0 {
+		r.data = append(r.data, p...)
+		return n, nil
+	}
+	if len(p) >= r.capacity {
+		r.dropped += int64(len(r.data) + len(p) - r.capacity)
+		r.data = append(r.data[:0], p[len(p)-r.capacity:]...)
+		r.start = 0
+		return n, nil
+	}
+	if room := r.capacity - len(r.data); room > 0 {
+		if len(p) <= room {
+			r.data = append(r.data, p...)
+			return n, nil
+		}
+		r.data = append(r.data, p[:room]...)
+		p = p[room:]
+	}
+	r.dropped += int64(len(p))
+	for len(p) > 0 {
+		copied := copy(r.data[r.start:], p)
+		p = p[copied:]
+		r.start = (r.start + copied) % r.capacity
+	}
+	return n, nil
+}
+
+// WriteString writes s to the buffer
+func (r *RingBuffer) WriteString(s string) (int, error) {
+	return r.Write([]byte(s))
+}
+
+// Len is the number of bytes retained
+func (r *RingBuffer) Len() int {
+	return len(r.data)
+}
+
+// Dropped is the number of bytes which were overwritten
+func (r *RingBuffer) Dropped() int64 {
+	return r.dropped
+}
+
+// Bytes returns the retained bytes, oldest first. The slice is valid until the next write.
+func (r *RingBuffer) Bytes() []byte {
+	if r.start != 0 {
+		r.data = append(r.data[r.start:len(r.data):len(r.data)], r.data[:r.start]...)
+		r.start = 0
+	}
+	return r.data
+}
+
+// String returns the retained bytes as a string
+func (r *RingBuffer) String() string {
+	return string(r.Bytes())
+}
@@ -0,0 +1,42 @@
+package vt10x
+
+// ANSI color values
+const (
+	Black Color = iota
+	Red
+	Green
+	Yellow
+	Blue
+	Magenta
+	Cyan
+	LightGrey
+	DarkGrey
+	LightRed
+	LightGreen
+	LightYellow
+	LightBlue
+	LightMagenta
+	LightCyan
+	White
+)
+
+// Default colors are potentially distinct to allow for special behavior.
+// For example, a transparent background. Otherwise, the simple case is to
+// map default colors to another color.
+const (
+	DefaultFG Color = 1<<24 + iota
+	DefaultBG
+	DefaultCursor
+)
+
+// TrueColor flags a 24-bit color set via SGR 38;2 or 48;2, distinguishing a color such as #00005f from the palette
+// index 95.
+const TrueColor Color = 1 << 25
+
+// Color maps to the ANSI colors [0, 16) and the xterm colors [16, 256).
+type Color uint32
+
+// ANSI returns true if Color is within [0, 16).
+func (c Color) ANSI() bool {
+	return (c < 16)
+}
@@ -0,0 +1,5 @@
+// Package vt10x is mimic's terminal emulator, a copy of github.com/hinshun/vt10x at v0.0.0-20220301184237-5011da428d02
+// (MIT licensed, see LICENSE). Upstream declares its terminal only for platforms with ptys, so it can't be built for
+// js; terminal.go is its vt_posix.go without those build constraints. Its ioctl helpers are omitted, as mimic sizes ptys
+// via github.com/creack/pty. Unlike upstream, 24-bit colors are flagged with TrueColor.
+package vt10x
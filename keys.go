@@ -0,0 +1,85 @@
+package mimic
+
+import "fmt"
+
+// Key is the byte sequence a terminal sends for a key press. See Mimic.SendKey.
+type Key string
+
+// Named keys, encoded as sent by xterm-compatible terminals in normal (non-application) cursor key mode.
+const (
+	KeyEnter     Key = "\r"
+	KeyTab       Key = "\t"
+	KeyBackspace Key = "\x7f"
+	KeyEscape    Key = "\x1b"
+	KeySpace     Key = " "
+
+	KeyArrowUp    Key = "\x1b[A"
+	KeyArrowDown  Key = "\x1b[B"
+	KeyArrowRight Key = "\x1b[C"
+	KeyArrowLeft  Key = "\x1b[D"
+
+	KeyHome     Key = "\x1b[H"
+	KeyEnd      Key = "\x1b[F"
+	KeyInsert   Key = "\x1b[2~"
+	KeyDelete   Key = "\x1b[3~"
+	KeyPageUp   Key = "\x1b[5~"
+	KeyPageDown Key = "\x1b[6~"
+
+	KeyF1  Key = "\x1bOP"
+	KeyF2  Key = "\x1bOQ"
+	KeyF3  Key = "\x1bOR"
+	KeyF4  Key = "\x1bOS"
+	KeyF5  Key = "\x1b[15~"
+	KeyF6  Key = "\x1b[17~"
+	KeyF7  Key = "\x1b[18~"
+	KeyF8  Key = "\x1b[19~"
+	KeyF9  Key = "\x1b[20~"
+	KeyF10 Key = "\x1b[21~"
+	KeyF11 Key = "\x1b[23~"
+	KeyF12 Key = "\x1b[24~"
+)
+
+// SendKey sends each key press to the application, in order.
+//
+//	err := m.SendKey(mimic.KeyArrowDown, mimic.KeyArrowDown, mimic.KeyEnter)
+func (m *Mimic) SendKey(keys ...Key) error {
+	for _, key := range keys {
+		if _, err := m.WriteString(string(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SendControl sends the control character produced by pressing Ctrl with c, e.g. SendControl('c') sends ETX (0x03).
+// c may be a letter (in either case) or one of @ [ \ ] ^ _ ?, per the conventional caret notation.
+func (m *Mimic) SendControl(c rune) error {
+	var b byte
+	switch {
+	case c >= 'a' && c <= 'z':
+		b = byte(c-'a') + 1
+	case c >= '@' && c <= '_':
+		b = byte(c - '@')
+	case c == '?':
+		b = 0x7f
+	default:
+		return fmt.Errorf("no control character for %q", c)
+	}
+	_, err := m.Write([]byte{b})
+	return err
+}
+
+// SendInterrupt sends Ctrl+C. When a process attached to the terminal is in the foreground, it receives SIGINT.
+func (m *Mimic) SendInterrupt() error {
+	return m.SendControl('c')
+}
+
+// SendEOF sends Ctrl+D, which ends input for an application reading from the terminal in canonical mode.
+func (m *Mimic) SendEOF() error {
+	return m.SendControl('d')
+}
+
+// SendSuspend sends Ctrl+Z. When a process attached to the terminal is in the foreground, it receives SIGTSTP.
+func (m *Mimic) SendSuspend() error {
+	return m.SendControl('z')
+}
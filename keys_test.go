@@ -0,0 +1,84 @@
+package mimic
+
+import (
+	"bufio"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_SendKey(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []Key
+		want string
+	}{
+		{name: "backspace erases", keys: []Key{"a", "b", "c", KeyBackspace, KeyEnter}, want: "ab\n"},
+		{name: "arrows", keys: []Key{KeyArrowDown, KeyArrowDown, KeyEnter}, want: "\x1b[B\x1b[B\n"},
+		{name: "function keys", keys: []Key{KeyF1, KeyF12, KeyEnter}, want: "\x1bOP\x1b[24~\n"},
+		{name: "navigation", keys: []Key{KeyHome, KeyEnd, KeyPageUp, KeyPageDown, KeyEnter}, want: "\x1b[H\x1b[F\x1b[5~\x1b[6~\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMimic(WithIdleTimeout(time.Second))
+			assert.NoError(t, err)
+			defer m.Close()
+
+			assert.NoError(t, m.SendKey(tt.keys...))
+
+			// the tty is in canonical mode, so the line discipline applies erase and translates the carriage return
+			line, err := bufio.NewReader(m.Tty()).ReadString('\n')
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, line)
+		})
+	}
+}
+
+func TestMimic_SendControl(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	// Ctrl+U kills the pending line in canonical mode
+	_, err = m.WriteString("abc")
+	assert.NoError(t, err)
+	assert.NoError(t, m.SendControl('U'))
+	assert.NoError(t, m.SendKey("xy", KeyEnter))
+
+	line, err := bufio.NewReader(m.Tty()).ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "xy\n", line)
+
+	assert.Error(t, m.SendControl('1'))
+}
+
+func TestMimic_SendInterrupt(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(2 * time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	p, err := m.Spawn(context.Background(), "sh", "-c", `trap 'echo interrupted; exit 4' INT; echo ready; while :; do sleep 0.05; done`)
+	assert.NoError(t, err)
+	assert.NoError(t, m.ExpectString("ready"))
+
+	assert.NoError(t, m.SendInterrupt())
+	assert.NoError(t, m.ExpectString("interrupted"))
+	_ = p.Wait()
+	assert.Equal(t, 4, p.ExitCode())
+}
+
+func TestMimic_SendEOF(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(2 * time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	p, err := m.Spawn(context.Background(), "sh", "-c", `echo ready; cat >/dev/null; echo "input closed"`)
+	assert.NoError(t, err)
+	assert.NoError(t, m.ExpectString("ready"))
+
+	assert.NoError(t, m.SendEOF())
+	assert.NoError(t, m.ExpectString("input closed"))
+	assert.NoError(t, p.Wait())
+}
@@ -0,0 +1,78 @@
+package mimic
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/jimschubert/stripansi"
+)
+
+// ExpectLine waits for a complete line of output to match pattern, returning the result of the match. See
+// Mimic.ExpectLineContext.
+func (m *Mimic) ExpectLine(pattern string) (MatchResult, error) {
+	return m.ExpectLineContext(context.Background(), pattern)
+}
+
+// ExpectLineContext waits for a complete line of output to match pattern, returning the result of the match. The
+// expectation is abandoned with ctx's error if ctx is done before a match is found.
+//
+// Unlike Mimic.ExpectPatternContext, which evaluates pattern against all output read so far, each line is evaluated
+// alone, once it's terminated by a newline. A pattern therefore can't match across unrelated output on neighboring
+// lines, nor match a line before it's completely written: `id: (\d+)` captures every digit of "id: 42", and `^Done$`
+// matches only a line which is exactly "Done". A line is evaluated as the text following its last carriage return,
+// i.e. the final frame of a line redrawn by a progress bar, and stripped of ANSI escape characters.
+//
+// An error wrapping ErrInvalidPattern is returned, without waiting, if pattern is invalid.
+func (m *Mimic) ExpectLineContext(ctx context.Context, pattern string) (MatchResult, error) {
+	criteria := []string{pattern}
+	re, err := CompilePattern(pattern)
+	if err != nil {
+		m.transcript.record("ExpectLine", criteria, "", err)
+		return MatchResult{}, err
+	}
+
+	matcher := &lineMatcher{re: re}
+	output, _, err := m.expect(ctx, matcher)
+	output = stripansi.String(output)
+	err = m.patternError(criteria, output, err, false)
+	m.transcript.record("ExpectLine", criteria, output, err)
+	if err != nil {
+		return MatchResult{}, err
+	}
+	return newMatchResult(pattern, re, matcher.line), nil
+}
+
+// lineMatcher matches re against each line of output as it's terminated, recording the line which matched. go-expect
+// evaluates matchers as each rune is read, so the line just terminated is the only one which needs evaluating.
+type lineMatcher struct {
+	re   *regexp.Regexp
+	line string
+}
+
+func (l *lineMatcher) Match(v interface{}) bool {
+	buf, ok := v.(*bytes.Buffer)
+	if !ok || buf.Len() == 0 || buf.Bytes()[buf.Len()-1] != '\n' {
+		return false
+	}
+
+	output := buf.Bytes()[:buf.Len()-1]
+	line := completedLine(stripansi.String(string(output[bytes.LastIndexByte(output, '\n')+1:])))
+	if l.re.MatchString(line) {
+		l.line = line
+		return true
+	}
+	return false
+}
+
+func (l *lineMatcher) Criteria() interface{} {
+	return l.re
+}
+
+// completedLine is the final text of a terminated line: the text following its last carriage return, disregarding the
+// carriage returns which precede a newline (a pty translates "\r\n" written by a program to "\r\r\n")
+func completedLine(line string) string {
+	line = strings.TrimRight(line, "\r")
+	return line[strings.LastIndexByte(line, '\r')+1:]
+}
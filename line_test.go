@@ -0,0 +1,63 @@
+package mimic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletedLine(t *testing.T) {
+	assert.Equal(t, "Done", completedLine("Done\r\r"))
+	assert.Equal(t, "Downloading 100%", completedLine("Downloading  10%\rDownloading 100%\r"))
+	assert.Equal(t, "", completedLine(""))
+}
+
+func TestMimic_ExpectLine(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(200 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	go func() {
+		for _, chunk := range []string{"Created user ", "\x1b[1mid: 4", "2\x1b[0m\r\n", "Download 50%\rDownload 100%\r\n"} {
+			_, _ = m.Tty().WriteString(chunk)
+			time.Sleep(20 * time.Millisecond)
+		}
+	}()
+
+	result, err := m.ExpectLine(`id: (\d+)`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"42"}, result.Groups, "the line should be evaluated once it's complete")
+	assert.Equal(t, "id: 42", result.Text)
+
+	result, err = m.ExpectLine(`^Download (\d+)%$`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"100"}, result.Groups, "only the final frame of a redrawn line should be evaluated")
+}
+
+func TestMimic_ExpectLine_unterminated(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(50 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Tty().WriteString("Password: ")
+	assert.NoError(t, err)
+
+	_, err = m.ExpectLine(`Password:`)
+	assert.ErrorIs(t, err, ErrExpectTimeout, "a line shouldn't match until it's terminated")
+
+	_, err = m.ExpectLine(`(`)
+	assert.ErrorIs(t, err, ErrInvalidPattern)
+}
+
+func TestMimic_ExpectLine_acrossLines(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(50 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Tty().WriteString("status: starting\r\nready\r\n")
+	assert.NoError(t, err)
+
+	_, err = m.ExpectLine(`(?s)status:.*ready`)
+	assert.ErrorIs(t, err, ErrExpectTimeout, "a pattern shouldn't match across lines")
+}
@@ -0,0 +1,59 @@
+package mimic
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// logger receives mimic's diagnostic messages, as a message followed by alternating keys and values.
+// See WithLogger, and the DEBUG environment variable used when no logger is configured.
+type logger interface {
+	debug(msg string, args ...interface{})
+	debugEnabled() bool
+}
+
+// writerLogger formats diagnostic messages to w when debugging is enabled via the DEBUG environment variable
+type writerLogger struct {
+	w io.Writer
+}
+
+func (l writerLogger) debug(msg string, args ...interface{}) {
+	if !l.debugEnabled() {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString("mimic: ")
+	sb.WriteString(msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		_, _ = fmt.Fprintf(&sb, " %v=%v", args[i], args[i+1])
+	}
+	sb.WriteString("\n")
+	_, _ = io.WriteString(l.w, sb.String())
+}
+
+func (l writerLogger) debugEnabled() bool {
+	return isDebugEnabled()
+}
+
+// consoleLogWriter adapts logger as the output of the underlying console's *log.Logger, one message per line
+type consoleLogWriter struct {
+	logger logger
+}
+
+func (c consoleLogWriter) Write(p []byte) (int, error) {
+	c.logger.debug(strings.TrimSuffix(string(p), "\n"), "source", "console")
+	return len(p), nil
+}
+
+func isDebugEnabled() bool {
+	if val, ok := os.LookupEnv("DEBUG"); ok {
+		debug, _ := strconv.ParseBool(val)
+		return debug
+	}
+
+	return false
+}
@@ -0,0 +1,34 @@
+//go:build go1.21
+
+package mimic
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithLogger routes mimic's diagnostic messages, including those of the underlying console, to logger at debug level.
+// When a logger is configured, the DEBUG environment variable is ignored in favor of logger's level. A nil logger
+// configures no logger, leaving diagnostics to the DEBUG environment variable.
+func WithLogger(logger *slog.Logger) Option {
+	return func(opt *mimicOpt) {
+		if logger == nil {
+			opt.logger = nil
+			return
+		}
+		opt.logger = slogLogger{logger: logger}
+	}
+}
+
+// slogLogger adapts a *slog.Logger to logger
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (s slogLogger) debug(msg string, args ...interface{}) {
+	s.logger.Debug(msg, args...)
+}
+
+func (s slogLogger) debugEnabled() bool {
+	return s.logger.Enabled(context.Background(), slog.LevelDebug)
+}
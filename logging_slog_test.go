@@ -0,0 +1,53 @@
+//go:build go1.21
+
+package mimic
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLogger(t *testing.T) {
+	t.Setenv("DEBUG", "false")
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	m, err := NewMimic(WithLogger(logger), WithFlushTimeout(10*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Tty().WriteString("Hello")
+	assert.NoError(t, err)
+	assert.False(t, m.ContainsPattern(`Goodbye`))
+
+	assert.Contains(t, buf.String(), `level=DEBUG msg="ContainsPattern failed" patterns=Goodbye`)
+	assert.Contains(t, buf.String(), `source=console`, "console diagnostics should be routed to the logger")
+}
+
+func TestWithLogger_respectsLevel(t *testing.T) {
+	t.Setenv("DEBUG", "true")
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	m, err := NewMimic(WithLogger(logger), WithFlushTimeout(10*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	assert.False(t, m.ContainsPattern(`Goodbye`))
+	assert.Empty(t, buf.String(), "the logger's level should take precedence over DEBUG")
+}
+
+func TestWithLogger_nil(t *testing.T) {
+	t.Setenv("DEBUG", "false")
+
+	m, err := NewMimic(WithLogger(nil), WithFlushTimeout(10*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	assert.IsType(t, writerLogger{}, m.logger, "a nil logger should leave diagnostics to DEBUG")
+	assert.False(t, m.ContainsPattern(`Goodbye`))
+}
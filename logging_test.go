@@ -0,0 +1,22 @@
+package mimic
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_writerLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := writerLogger{w: &buf}
+
+	t.Setenv("DEBUG", "false")
+	l.debug("ignored")
+	assert.Empty(t, buf.String())
+
+	t.Setenv("DEBUG", "true")
+	l.debug("ContainsString failed to flush", "error", errors.New("boom"))
+	assert.Equal(t, "mimic: ContainsString failed to flush error=boom\n", buf.String())
+}
@@ -0,0 +1,267 @@
+package mimic
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/search"
+	"golang.org/x/text/unicode/norm"
+)
+
+// matching configures how plain strings are compared against terminal contents
+type matching struct {
+	locale          *language.Tag
+	graphemes       bool
+	caseInsensitive bool
+	whitespace      bool
+	spinners        bool
+	placeholders    bool
+}
+
+// spinnerMask replaces spinner glyphs when comparing plain strings with WithSpinnerTolerance
+const spinnerMask = '⣿'
+
+// asciiSpinner are the frames of the classic ASCII spinner, which are masked only where they stand alone
+const asciiSpinner = `|/-\`
+
+// WithLocale enables locale-aware comparison of plain strings in functions such as Mimic.ContainsString and
+// Mimic.ExpectString. Comparisons follow the collation rules of the given language: case is folded per the locale,
+// diacritics and character width are ignored, and collation-equivalent sequences match one another
+// (e.g. "ç" matches "c", and "ß" matches "ss" in German). Patterns are unaffected.
+func WithLocale(tag language.Tag) Option {
+	return func(opt *mimicOpt) {
+		opt.matching.locale = &tag
+	}
+}
+
+// WithGraphemeNormalization compares plain strings in functions such as Mimic.ContainsString and Mimic.ExpectString
+// by grapheme cluster (i.e. user-perceived character), after normalizing each cluster to NFC and removing variation
+// selectors. Text which renders identically then matches: "é" matches "e" followed by a combining acute accent, and
+// "❤️" matches "❤" without the emoji variation selector. A match must begin and end on cluster boundaries, so "e"
+// doesn't match the first half of "é", and "👨" doesn't match part of the family emoji "👨‍👩‍👧".
+//
+// Note that expectations are evaluated as output arrives, so a match at the end of output may be satisfied before a
+// combining character which follows it. Patterns are unaffected.
+func WithGraphemeNormalization() Option {
+	return func(opt *mimicOpt) {
+		opt.matching.graphemes = true
+	}
+}
+
+// WithCaseInsensitive ignores case when comparing plain strings in functions such as Mimic.ContainsString and
+// Mimic.ExpectString, so "password:" matches "Password:". Case is folded per Unicode rather than per language; see
+// WithLocale for locale-aware comparisons, which already ignore case. Patterns are unaffected, aside from {{name}}
+// placeholders in plain strings. See WithCallCaseInsensitive to ignore case for a single call.
+func WithCaseInsensitive() Option {
+	return func(opt *mimicOpt) {
+		opt.matching.caseInsensitive = true
+	}
+}
+
+// WithCallCaseInsensitive ignores case when comparing plain strings, as WithCaseInsensitive, for operations invoked on
+// the Mimic returned by Mimic.With:
+//
+//	err := m.With(mimic.WithCallCaseInsensitive()).ExpectString("password:")
+func WithCallCaseInsensitive() CallOption {
+	return func(m *Mimic) {
+		m.matching.caseInsensitive = true
+	}
+}
+
+// WithWhitespaceNormalization collapses runs of whitespace, including line breaks, into a single space when comparing
+// plain strings in functions such as Mimic.ContainsString and Mimic.ExpectString. Rows which the terminal wrapped are
+// rejoined first, so ContainsString("What is your name?") succeeds when a narrow terminal wraps the question across
+// two rows. Patterns are unaffected. See WithCallWhitespaceNormalization to normalize whitespace for a single call.
+func WithWhitespaceNormalization() Option {
+	return func(opt *mimicOpt) {
+		opt.matching.whitespace = true
+	}
+}
+
+// WithCallWhitespaceNormalization collapses whitespace when comparing plain strings, as WithWhitespaceNormalization,
+// for operations invoked on the Mimic returned by Mimic.With.
+func WithCallWhitespaceNormalization() CallOption {
+	return func(m *Mimic) {
+		m.matching.whitespace = true
+	}
+}
+
+// WithSpinnerTolerance masks the frames of common spinners when comparing plain strings in functions such as
+// Mimic.ContainsString and Mimic.ExpectString, so "⠙ Building…" matches "⠹ Building…" regardless of which frame was on
+// screen. Braille glyphs (e.g. ⠋⠙⠹⠸) are masked wherever they appear, while the ASCII frames |, /, -, and \ are masked
+// only where they stand alone between whitespace or the start and end of a line, leaving "1/2" and "--help" intact.
+// Masked glyphs match one another, so frames from either set are interchangeable. Patterns, including plain strings
+// with {{name}} placeholders, are unaffected. See WithCallSpinnerTolerance to mask spinners for a single call.
+func WithSpinnerTolerance() Option {
+	return func(opt *mimicOpt) {
+		opt.matching.spinners = true
+	}
+}
+
+// WithCallSpinnerTolerance masks spinner frames when comparing plain strings, as WithSpinnerTolerance, for operations
+// invoked on the Mimic returned by Mimic.With:
+//
+//	err := m.With(mimic.WithCallSpinnerTolerance()).ExpectString("⠋ Building…")
+func WithCallSpinnerTolerance() CallOption {
+	return func(m *Mimic) {
+		m.matching.spinners = true
+	}
+}
+
+// WithPlaceholders expands registered {{name}} placeholders (see RegisterMatcher) in plain strings passed to functions
+// such as Mimic.ContainsString and Mimic.ExpectString, which then match the string literally aside from its
+// placeholders. Otherwise, plain strings are matched as written, braces included; patterns always expand placeholders.
+// See WithCallPlaceholders to expand placeholders for a single call.
+func WithPlaceholders() Option {
+	return func(opt *mimicOpt) {
+		opt.matching.placeholders = true
+	}
+}
+
+// WithCallPlaceholders expands registered {{name}} placeholders in plain strings, as WithPlaceholders, for operations
+// invoked on the Mimic returned by Mimic.With:
+//
+//	err := m.With(mimic.WithCallPlaceholders()).ExpectString("request {{uuid}} took {{duration}}")
+func WithCallPlaceholders() CallOption {
+	return func(m *Mimic) {
+		m.matching.placeholders = true
+	}
+}
+
+// containsFunc returns the comparison used to find substr in s, or nil if plain strings.Contains semantics apply
+func (c matching) containsFunc() func(s, substr string) bool {
+	var contains func(s, substr string) bool
+	switch {
+	case c.locale != nil:
+		matcher := search.New(*c.locale, search.Loose)
+		contains = func(s, substr string) bool {
+			start, _ := matcher.IndexString(s, substr)
+			return start >= 0
+		}
+	case c.graphemes:
+		contains = containsGraphemes
+	case c.caseInsensitive, c.whitespace, c.spinners:
+		contains = strings.Contains
+	default:
+		return nil
+	}
+
+	normalizers := c.normalizers()
+	if len(normalizers) == 0 {
+		return contains
+	}
+	return func(s, substr string) bool {
+		for _, normalize := range normalizers {
+			s, substr = normalize(s), normalize(substr)
+		}
+		return contains(s, substr)
+	}
+}
+
+// normalizers are applied to both strings before they're compared
+func (c matching) normalizers() []func(string) string {
+	var normalizers []func(string) string
+	if c.graphemes && c.locale != nil {
+		// containsGraphemes normalizes clusters itself, but the locale's comparison doesn't
+		normalizers = append(normalizers, func(s string) string {
+			return strings.Join(graphemes(s), "")
+		})
+	}
+	if c.caseInsensitive && c.locale == nil {
+		normalizers = append(normalizers, func(s string) string {
+			// a Caser is stateful, so can't be shared by concurrent comparisons
+			return cases.Fold().String(s)
+		})
+	}
+	if c.whitespace {
+		normalizers = append(normalizers, func(s string) string {
+			return strings.Join(strings.Fields(s), " ")
+		})
+	}
+	if c.spinners {
+		normalizers = append(normalizers, maskSpinners)
+	}
+	return normalizers
+}
+
+// maskSpinners replaces the spinner frames of s with spinnerMask
+func maskSpinners(s string) string {
+	runes := []rune(s)
+	standalone := func(i int) bool {
+		return (i == 0 || unicode.IsSpace(runes[i-1])) && (i == len(runes)-1 || unicode.IsSpace(runes[i+1]))
+	}
+	for i, r := range runes {
+		switch {
+		case r >= '\u2800' && r <= '\u28FF':
+			runes[i] = spinnerMask
+		case strings.ContainsRune(asciiSpinner, r) && standalone(i):
+			runes[i] = spinnerMask
+		}
+	}
+	return string(runes)
+}
+
+// unwrappedView is the text of the view with rows which the terminal wrapped joined to the row which follows them, so
+// each line of output is on a single line
+func (m *Mimic) unwrappedView() string {
+	m.terminal.Lock()
+	defer m.terminal.Unlock()
+
+	columns, rows := m.terminal.Size()
+	var sb strings.Builder
+	for y := 0; y < rows; y++ {
+		for x := 0; x < columns; x++ {
+			sb.WriteRune(m.terminal.Cell(x, y).Char)
+		}
+		if m.terminal.Cell(columns-1, y).Mode&glyphWrap == 0 {
+			sb.WriteByte('\n')
+		}
+	}
+	return withoutSpacers(sb.String())
+}
+
+// containsGraphemes reports whether the grapheme clusters of substr occur consecutively in s
+func containsGraphemes(s, substr string) bool {
+	haystack, needle := graphemes(s), graphemes(substr)
+	for start := 0; start+len(needle) <= len(haystack); start++ {
+		matched := true
+		for i, cluster := range needle {
+			if haystack[start+i] != cluster {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// graphemes splits s into grapheme clusters, each normalized to NFC without variation selectors
+func graphemes(s string) []string {
+	var clusters []string
+	state := -1
+	for len(s) > 0 {
+		var cluster string
+		cluster, s, _, state = uniseg.FirstGraphemeClusterInString(s, state)
+		cluster = strings.Map(func(r rune) rune {
+			if isVariationSelector(r) {
+				return -1
+			}
+			return r
+		}, norm.NFC.String(cluster))
+		if cluster != "" {
+			clusters = append(clusters, cluster)
+		}
+	}
+	return clusters
+}
+
+// isVariationSelector reports whether r selects a variant glyph (e.g. emoji or text presentation) of the rune before it
+func isVariationSelector(r rune) bool {
+	return r >= 0xfe00 && r <= 0xfe0f || r >= 0xe0100 && r <= 0xe01ef
+}
@@ -0,0 +1,146 @@
+package mimic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestMatching_containsFunc(t *testing.T) {
+	french, german := language.French, language.German
+	tests := []struct {
+		name     string
+		matching matching
+		s        string
+		substr   string
+		want     bool
+	}{
+		{name: "locale ignores diacritics and case", matching: matching{locale: &french}, s: "Ça va?", substr: "ca va", want: true},
+		{name: "locale matches collation equivalents", matching: matching{locale: &german}, s: "Straße öffnen", substr: "STRASSE ÖFFNEN", want: true},
+		{name: "locale still distinguishes text", matching: matching{locale: &german}, s: "Straße öffnen", substr: "Weg", want: false},
+		{name: "graphemes match combining characters", matching: matching{graphemes: true}, s: "cafe\u0301 ouvert", substr: "café", want: true},
+		{name: "graphemes match precomposed characters", matching: matching{graphemes: true}, s: "café ouvert", substr: "cafe\u0301", want: true},
+		{name: "graphemes ignore variation selectors", matching: matching{graphemes: true}, s: "I ❤\ufe0f Go", substr: "I ❤ Go", want: true},
+		{name: "graphemes with locale", matching: matching{graphemes: true, locale: &french}, s: "CAFÉ ❤\ufe0f", substr: "café ❤", want: true},
+		{name: "case folds", matching: matching{caseInsensitive: true}, s: "Password:", substr: "password:", want: true},
+		{name: "case folds Greek", matching: matching{caseInsensitive: true}, s: "ΣΥΝΔΕΣΗ OK", substr: "συνδεση ok", want: true},
+		{name: "case distinguishes accents", matching: matching{caseInsensitive: true}, s: "ΣΥΝΔΕΣΗ OK", substr: "σύνδεση ok", want: false},
+		{name: "case with graphemes", matching: matching{caseInsensitive: true, graphemes: true}, s: "CAFÉ", substr: "café", want: true},
+		{name: "whitespace collapses spaces", matching: matching{whitespace: true}, s: "Name:   Jim", substr: "Name: Jim", want: true},
+		{name: "whitespace collapses line breaks", matching: matching{whitespace: true}, s: "Name:\r\n\tJim", substr: "Name: Jim", want: true},
+		{name: "whitespace isn't removed", matching: matching{whitespace: true}, s: "Name:   Jim", substr: "Name:Jim", want: false},
+		{name: "whitespace with case", matching: matching{whitespace: true, caseInsensitive: true}, s: "NAME:  JIM", substr: "name: jim", want: true},
+		{name: "spinners are interchangeable", matching: matching{spinners: true}, s: "/ Building…", substr: "⠙ Building…", want: true},
+		{name: "spinners still distinguish text", matching: matching{spinners: true}, s: "⠹ Building…", substr: "⠙ Testing…", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contains := tt.matching.containsFunc()
+			assert.NotNil(t, contains)
+			assert.Equal(t, tt.want, contains(tt.s, tt.substr))
+		})
+	}
+
+	assert.Nil(t, matching{}.containsFunc(), "plain strings should be compared exactly by default")
+}
+
+func TestMaskSpinners(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{s: "⠹ Building…", want: "⣿ Building…"},
+		{s: "Working \\", want: "Working ⣿"},
+		{s: "| - /", want: "⣿ ⣿ ⣿"},
+		{s: "Step 1/2", want: "Step 1/2"},
+		{s: "mimic --help", want: "mimic --help"},
+		{s: "done", want: "done"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, maskSpinners(tt.s), tt.s)
+	}
+}
+
+func TestContainsGraphemes(t *testing.T) {
+	assert.True(t, containsGraphemes("naïve", "naïve"))
+	assert.False(t, containsGraphemes("cafe\u0301", "cafe"), "matches should end on a cluster boundary")
+	assert.False(t, containsGraphemes("\U0001F468‍\U0001F469‍\U0001F467", "\U0001F468"), "matches shouldn't split emoji sequences")
+	assert.True(t, containsGraphemes("family: \U0001F468‍\U0001F469‍\U0001F467", "\U0001F468‍\U0001F469‍\U0001F467"))
+	assert.True(t, containsGraphemes("anything", ""))
+}
+
+func TestMatching_mimic(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		contents string
+		expected string
+		want     bool
+	}{
+		{name: "exact comparison by default", contents: "Ça va?", expected: "ca va", want: false},
+		{name: "locale", opts: []Option{WithLocale(language.French)}, contents: "Ça va?", expected: "ca va", want: true},
+		{name: "graphemes", opts: []Option{WithGraphemeNormalization()}, contents: "cafe\u0301 ouvert", expected: "café", want: true},
+		{name: "case", opts: []Option{WithCaseInsensitive()}, contents: "Password:", expected: "password:", want: true},
+		{name: "case applies to placeholders", opts: []Option{WithCaseInsensitive(), WithPlaceholders()}, contents: "VERSION v1.2.3", expected: "version {{semver}}", want: true},
+		{name: "whitespace", opts: []Option{WithWhitespaceNormalization()}, contents: "Name:   Jim", expected: "Name: Jim", want: true},
+		{name: "spinners", opts: []Option{WithSpinnerTolerance()}, contents: "⠹ Building…", expected: "⠙ Building…", want: true},
+		{name: "differing text still fails", opts: []Option{WithCaseInsensitive()}, contents: "Password:", expected: "passphrase:", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMimic(append(tt.opts, WithIdleTimeout(50*time.Millisecond))...)
+			assert.NoError(t, err)
+			defer m.Close()
+
+			_, err = m.Tty().WriteString(tt.contents)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.want, m.ExpectString(tt.expected) == nil, "ExpectString")
+			assert.Equal(t, tt.want, m.ContainsString(tt.expected), "ContainsString")
+		})
+	}
+}
+
+func TestWithCallCaseInsensitive(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(50 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Tty().WriteString("Password:")
+	assert.NoError(t, err)
+
+	assert.NoError(t, m.With(WithCallCaseInsensitive()).ExpectString("PASSWORD:"))
+	assert.True(t, m.With(WithCallCaseInsensitive()).ContainsString("password:"))
+	assert.False(t, m.ContainsString("password:"), "the original Mimic should remain case sensitive")
+}
+
+func TestWithWhitespaceNormalization_wrapped(t *testing.T) {
+	m, err := NewMimic(WithSize(4, 10), WithIdleTimeout(50*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Tty().WriteString("Hi!\r\nWhat is your name? ")
+	assert.NoError(t, err)
+
+	// the question wraps mid-word: "What is yo" / "ur name? "
+	assert.False(t, m.ContainsString("What is your name?"))
+	assert.True(t, m.With(WithCallWhitespaceNormalization()).ContainsString("What is your name?"),
+		"rows wrapped by the terminal should be rejoined")
+	assert.True(t, m.With(WithCallWhitespaceNormalization()).ContainsString("Hi! What"),
+		"line breaks should be collapsed")
+}
+
+func TestWithCallSpinnerTolerance(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(50 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Tty().WriteString("⠋ Building…\r⠸ Building…")
+	assert.NoError(t, err)
+
+	assert.NoError(t, m.With(WithCallSpinnerTolerance()).ExpectString("⠙ Building…"))
+	assert.True(t, m.With(WithCallSpinnerTolerance()).ContainsString("| Building…"))
+	assert.False(t, m.ContainsString("⠙ Building…"), "the original Mimic should compare frames exactly")
+}
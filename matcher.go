@@ -0,0 +1,142 @@
+package mimic
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/jimschubert/mimic/internal/expect"
+	"github.com/jimschubert/stripansi"
+)
+
+// StreamView is what a Matcher observes each time it's evaluated during an expectation: the output read so far, and
+// the emulated terminal's view.
+type StreamView struct {
+	// Output is the output read during the expectation so far, stripped of ANSI escape characters
+	Output string
+	// Raw is the output read during the expectation so far, including ANSI escape sequences
+	Raw string
+
+	m *Mimic
+}
+
+// Lines returns the rows of the emulated terminal's view, stripped of ANSI escape characters and trimmed (see
+// Viewer.Lines).
+func (s StreamView) Lines() []string {
+	v := Viewer{Mimic: s.m, StripAnsi: true, Trim: true}
+	return v.Lines()
+}
+
+// Screen returns the emulated terminal's view as a single string, formatted as in Lines
+func (s StreamView) Screen() string {
+	v := Viewer{Mimic: s.m, StripAnsi: true, Trim: true}
+	return v.String()
+}
+
+// Cursor returns the zero-based row and column of the emulated terminal's cursor
+func (s StreamView) Cursor() (row, column int) {
+	if s.m == nil {
+		return 0, 0
+	}
+	return s.m.cursor()
+}
+
+// Matcher is a custom expectation, such as a JSON-aware or checksum-based comparison, for use with
+// Mimic.ExpectMatcher. Match is evaluated as output arrives, and periodically while no output arrives, until it
+// reports true. Output isn't read while Match is evaluated, so it shouldn't block.
+type Matcher interface {
+	// Match reports whether view satisfies the expectation
+	Match(view StreamView) bool
+	// Criteria describes the expectation in errors, traces, and transcripts
+	Criteria() string
+}
+
+// MatchFunc creates a Matcher from match, described by criteria
+func MatchFunc(criteria string, match func(view StreamView) bool) Matcher {
+	if match == nil {
+		return nil
+	}
+	return funcMatcher{criteria: criteria, match: match}
+}
+
+type funcMatcher struct {
+	criteria string
+	match    func(view StreamView) bool
+}
+
+func (f funcMatcher) Match(view StreamView) bool {
+	return f.match(view)
+}
+
+func (f funcMatcher) Criteria() string {
+	return f.criteria
+}
+
+// ExpectFunc waits for match to report true, returning an error if it doesn't before the idle timeout (see
+// WithIdleTimeout and WithCallTimeout). See Mimic.ExpectMatcher.
+//
+//	err := m.ExpectFunc(func(view mimic.StreamView) bool {
+//		return json.Valid([]byte(view.Output))
+//	})
+func (m *Mimic) ExpectFunc(match func(view StreamView) bool) error {
+	if match == nil {
+		return ErrNilMatcher
+	}
+	_, err := m.ExpectMatcher(MatchFunc("func", match))
+	return err
+}
+
+// ExpectMatcher waits for any of matchers to match, returning the first which does. See Mimic.ExpectMatcherContext.
+func (m *Mimic) ExpectMatcher(matchers ...Matcher) (Matcher, error) {
+	return m.ExpectMatcherContext(context.Background(), matchers...)
+}
+
+// ExpectMatcherContext waits for any of matchers to match, returning the first which does. The expectation is
+// abandoned with ctx's error if ctx is done before a match is found, and fails with a PatternError, as
+// Mimic.ExpectPattern does, if none match before the idle timeout. Output read during the expectation is consumed, so
+// later expectations observe only output which follows it.
+func (m *Mimic) ExpectMatcherContext(ctx context.Context, matchers ...Matcher) (Matcher, error) {
+	adapters := make([]expect.Matcher, 0, len(matchers))
+	criteria := make([]string, 0, len(matchers))
+	for _, matcher := range matchers {
+		if matcher == nil {
+			return nil, ErrNilMatcher
+		}
+		adapters = append(adapters, &streamMatcher{m: m, matcher: matcher})
+		criteria = append(criteria, matcher.Criteria())
+	}
+
+	output, matched, err := m.expect(ctx, adapters...)
+	output = stripansi.String(output)
+	err = m.patternError(criteria, output, err, false)
+	m.transcript.record("ExpectMatcher", criteria, output, err)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, adapter := range adapters {
+		if adapter == matched {
+			return matchers[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// streamMatcher adapts a Matcher to go-expect. go-expect evaluates matchers with the output read so far, or with a
+// read error such as a timeout; the latter is evaluated against the most recent output, so that matchers observing
+// the view are evaluated while no output arrives.
+type streamMatcher struct {
+	m       *Mimic
+	matcher Matcher
+	raw     string
+}
+
+func (s *streamMatcher) Match(v interface{}) bool {
+	if buf, ok := v.(*bytes.Buffer); ok {
+		s.raw = buf.String()
+	}
+	return s.matcher.Match(StreamView{Output: stripansi.String(s.raw), Raw: s.raw, m: s.m})
+}
+
+func (s *streamMatcher) Criteria() interface{} {
+	return s.matcher.Criteria()
+}
@@ -0,0 +1,82 @@
+package mimic
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_ExpectFunc(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(100 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	go func() {
+		for _, chunk := range []string{`{"status": `, "\x1b[32m", `"ok"`, "\x1b[0m", `}`} {
+			_, _ = m.Tty().WriteString(chunk)
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	var decoded map[string]string
+	err = m.ExpectFunc(func(view StreamView) bool {
+		return json.Unmarshal([]byte(view.Output), &decoded) == nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"status": "ok"}, decoded)
+
+	err = m.ExpectFunc(func(view StreamView) bool { return false })
+	assert.ErrorIs(t, err, ErrExpectTimeout)
+	assert.ErrorIs(t, m.ExpectFunc(nil), ErrNilMatcher)
+}
+
+func TestMimic_ExpectFunc_view(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(100 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Tty().WriteString("\x1b[31mready\x1b[0m")
+	assert.NoError(t, err)
+	assert.NoError(t, m.Flush())
+
+	// the view satisfies the matcher before the expectation begins, so it's evaluated without further output
+	err = m.ExpectFunc(func(view StreamView) bool {
+		row, column := view.Cursor()
+		return view.Lines()[0] == "ready" && view.Screen() != "" && row == 0 && column == 5
+	})
+	assert.NoError(t, err)
+}
+
+func TestMimic_ExpectMatcherContext(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(5 * time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = m.Tty().WriteString("sha256: 2cf24dba\r\n")
+	}()
+
+	checksum := MatchFunc("checksum", func(view StreamView) bool {
+		return strings.Contains(view.Output, "sha256: 2cf24dba")
+	})
+	failure := MatchFunc("failure", func(view StreamView) bool {
+		return strings.Contains(view.Raw, "error")
+	})
+	matched, err := m.ExpectMatcher(failure, checksum)
+	assert.NoError(t, err)
+	assert.Equal(t, "checksum", matched.Criteria())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = m.ExpectMatcherContext(ctx, failure)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "failure")
+
+	_, err = m.ExpectMatcher(checksum, nil)
+	assert.ErrorIs(t, err, ErrNilMatcher)
+}
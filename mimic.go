@@ -3,19 +3,17 @@ package mimic
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"io"
 	"log"
 	"os"
 	"regexp"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/Netflix/go-expect"
-	creakpty "github.com/creack/pty"
-	"github.com/hinshun/vt10x"
 	"github.com/jimschubert/mimic/internal"
+	"github.com/jimschubert/mimic/internal/expect"
+	"github.com/jimschubert/mimic/internal/vt10x"
 )
 
 const (
@@ -38,10 +36,32 @@ type mimicOpt struct {
 	in             io.Reader
 	maxIdleTimeout time.Duration
 	idleDuration   time.Duration
+	idlePoll       time.Duration
 	flushTimeout   time.Duration
 	rows           int
 	columns        int
 	pipeFromOS     bool
+	idleStrategy   IdleStrategy
+	inMemory       bool
+	deadline       time.Time
+	env            []string
+	grow           bool
+	maxRows        int
+	logOutput      io.Writer
+	logger         logger
+	trace          io.Writer
+	matching       matching
+	recording      *recordingOpt
+	transcript     bool
+	strict         bool
+	diffColor      bool
+	detachKey      byte
+	throttle       throttleOpt
+	faults         FaultInjection
+	maxBuffer      int
+	history        bool
+	scrollback     int
+	queryResponses QueryResponses
 }
 
 // Option extends functionality of Mimic via functional options.
@@ -70,6 +90,17 @@ func WithIdleDuration(duration time.Duration) Option {
 	}
 }
 
+// WithMaxBuffer bounds the output an expectation retains for matching to the most recent bytes, so that expectations
+// which wait through a lot of output (e.g. a long-running daemon's logs) use bounded memory. Every byte is still
+// rendered, so the view remains accurate; only matching and the output reported by expectations (e.g. in a
+// PatternError or to OnMatch handlers) are limited to the most recent bytes. Criteria must fit within the bound to
+// match. A value of zero or less, the default, retains all output read during an expectation.
+func WithMaxBuffer(bytes int) Option {
+	return func(opt *mimicOpt) {
+		opt.maxBuffer = bytes
+	}
+}
+
 // WithOutput writes a copy of emulated console output to w
 // Not compatible with WithStdout
 func WithOutput(w io.Writer) Option {
@@ -103,60 +134,96 @@ func WithSize(rows, columns int) Option {
 	}
 }
 
-// Mimic is a utility for mimicking operations on a pseudo terminal
+// Mimic is a utility for mimicking operations on a pseudo terminal.
+//
+// A Mimic is safe for concurrent use: for example, one goroutine may wait in ExpectString while another writes.
+// Expectations read (and consume) output from a single stream, so concurrent expectations are serialized: each waits
+// for those already in progress to complete before reading output. Operations which inspect the view, such as
+// ContainsString, don't wait for an in-progress expectation, which is already reading output into the view.
 type Mimic struct {
-	console      *expect.Console
+	console      console
+	device       io.ReadWriteCloser
+	reading      *sync.Mutex
+	closed       chan struct{}
+	closeOnce    *sync.Once
+	consoleOnce  *sync.Once
 	terminal     vt10x.Terminal
 	maxIdleWait  time.Duration
 	idleDuration time.Duration
+	idlePoll     time.Duration
+	idle         *idleWatchers
+	idleStrategy IdleStrategy
+	deadline     time.Time
+	env          []string
 	flushTimeout time.Duration
+	resize       *resizeHandlers
+	observers    *observers
+	events       *events
+	stats        *stats
+	modes        *modeTracker
+	recording    *recording
+	trace        *tracer
+	transcript   *transcript
+	strict       *strictTracker
+	logger       logger
+	matching     matching
+	diffColor    bool
+	detachKey    byte
+	passthrough  *passthrough
+	faults       FaultInjection
+	maxBuffer    int
+	history      *history
+	scrollback   *scrollback
+	bells        *bells
+	hyperlinks   *hyperlinks
+	clipboard    *clipboard
+	queries      *queryHandlers
+	cursorShape  *cursorShape
 	Experimental Experimental
 }
 
-// WaitForIdle causes the emulated terminal to spin, waiting the terminal output to "stabilize" (i.e. no writes are occurring)
-func (m *Mimic) WaitForIdle(ctx context.Context) error {
-	done := make(chan struct{})
-	timeoutContext, cancel := context.WithTimeout(ctx, m.maxIdleWait)
-	defer cancel()
-	go func() {
-		defer close(done)
-		var coord vt10x.Cursor
-		emptyCoord := vt10x.Cursor{}
-
-		started := time.Now()
-		for {
-			if timeoutContext.Err() != nil {
-				// context is completed before we begin iteration
-				return
-			}
-
-			if coord != m.terminal.Cursor() {
-				coord = vt10x.Cursor{}
-				started = time.Now()
-			}
-
-			if coord != emptyCoord && time.Now().Sub(started) >= m.idleDuration {
-				done <- struct{}{}
-				return
-			}
-
-			coord = m.terminal.Cursor()
-			time.Sleep(1 * time.Millisecond)
-		}
+// WaitForIdle waits for the terminal output to "stabilize" (i.e. no writes are occurring), returning an error if it
+// doesn't before the idle timeout. How stability is determined can be configured via WithIdleStrategy.
+func (m *Mimic) WaitForIdle(ctx context.Context) (err error) {
+	began := time.Now()
+	defer func() {
+		m.stats.waited(began)
+		m.trace.completed(TraceIdle, began, nil, err)
 	}()
 
-	select {
-	case <-timeoutContext.Done():
-		// we didn't stabilize :(
-		return timeoutContext.Err()
-	case <-done:
-		return nil
+	switch m.idleStrategy {
+	case OutputActivity:
+		return m.waitForOutputIdle(ctx)
+	case ContentStable:
+		return m.waitForStableState(ctx, m.contentState, true)
+	default:
+		return m.waitForStableState(ctx, m.cursorState, false)
 	}
 }
 
 // WriteString writes a value to the underlying terminal
 func (m *Mimic) WriteString(str string) (int, error) {
-	return m.console.Send(str)
+	allowed, fault := m.faults.write([]byte(str))
+	var n int
+	var err error
+	if len(allowed) > 0 || fault == nil {
+		n, err = m.console.Send(string(allowed))
+	}
+	if err == nil {
+		err = fault
+	}
+	err = wrapConsoleError(err)
+	if n > 0 {
+		m.stats.written(n)
+		m.recording.input([]byte(str[:n]))
+		m.observers.write([]byte(str[:n]))
+	}
+	m.trace.record(TraceEvent{Event: TraceWrite, Data: str[:n], Error: errorString(err)})
+	if err == nil {
+		m.strict.sent(str[:n])
+	}
+	m.transcript.record("WriteString", []string{str}, "", err)
+	return n, err
 }
 
 // Write writes a value to the underlying terminal.
@@ -168,112 +235,250 @@ func (m *Mimic) Write(b []byte) (int, error) {
 // Read bytes from the underlying terminal
 // Fulfills the io.Reader interface.
 func (m *Mimic) Read(p []byte) (n int, err error) {
-	return m.console.Tty().Read(p)
+	return m.device.Read(p)
 }
 
-// Close causes any underlying emulation to close.
+// Close causes any underlying emulation to close. Expectations pending on other goroutines return ErrClosed.
+// Close may be invoked multiple times; invocations after the first do nothing and return nil.
 // Fulfills the io.Closer interface.
 func (m *Mimic) Close() (err error) {
-	return m.console.Close()
+	m.markClosed()
+	m.consoleOnce.Do(func() {
+		err = m.console.Close()
+		m.events.close()
+	})
+	return err
+}
+
+// IsClosed reports whether the Mimic has been closed via Close, Shutdown, or CloseWithTimeout
+func (m *Mimic) IsClosed() bool {
+	select {
+	case <-m.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// markClosed signals that the Mimic is closed, abandoning pending expectations
+func (m *Mimic) markClosed() {
+	m.closeOnce.Do(func() {
+		close(m.closed)
+	})
+}
+
+// Shutdown closes the Mimic without losing output which was written to the terminal but not yet read. Expectations
+// pending on other goroutines return ErrClosed, as with Close. The terminal is then closed for writing, and output is
+// read into the view until the underlying reader reaches the end of the stream, after which the Mimic is closed.
+//
+// Processes attached to the terminal (see Mimic.Spawn) hold it open, so the end of the stream may not be reached while
+// they run. If ctx is done before the end of the stream is reached, the Mimic is closed regardless and ctx's error is
+// returned.
+// Shutdown does nothing and returns nil if the Mimic is already closed.
+func (m *Mimic) Shutdown(ctx context.Context) error {
+	if m.IsClosed() {
+		return nil
+	}
+	m.markClosed()
+
+	m.reading.Lock()
+	drainErr := m.drain(ctx)
+	m.reading.Unlock()
+
+	if err := m.Close(); err != nil {
+		return err
+	}
+	return drainErr
+}
+
+// CloseWithTimeout closes the Mimic as Shutdown does, waiting at most timeout for unread output to be drained
+func (m *Mimic) CloseWithTimeout(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return m.Shutdown(ctx)
+}
+
+// drain closes the terminal for writing, then reads output until the end of the stream or ctx is done.
+// The caller must hold m.reading.
+func (m *Mimic) drain(ctx context.Context) error {
+	// once no writers remain, reads of the pty report the closed terminal after all written output is read
+	_ = m.device.Close()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		timeout := &internal.TimeoutMatcher{}
+		buf, err := m.expectConsole(
+			expect.WithTimeout(expectPollInterval),
+			expect.EOF,
+			expect.PTSClosed,
+			func(opts *expect.ExpectOpts) error {
+				opts.Matchers = append(opts.Matchers, timeout, &internal.ContextMatcher{Ctx: ctx})
+				return nil
+			},
+		)
+		m.strict.read(buf)
+		if err != nil {
+			return wrapConsoleError(err)
+		}
+		if timeout.Err == nil && ctx.Err() == nil {
+			// the end of the stream was reached
+			return nil
+		}
+	}
 }
 
 // Flush (or attempt to flush) any pending writes done via Write or WriteString.
+// If an expectation is in progress on another goroutine, Flush returns immediately: that expectation is already
+// reading pending output into the view.
 func (m *Mimic) Flush() error {
-	_, err := m.console.Expect(expect.WithTimeout(m.flushTimeout), func(opts *expect.ExpectOpts) error {
+	if !m.reading.TryLock() {
+		return nil
+	}
+	defer m.reading.Unlock()
+
+	began := time.Now()
+	buf, err := m.expectConsole(expect.WithTimeout(m.flushTimeout), func(opts *expect.ExpectOpts) error {
 		opts.Matchers = append(opts.Matchers, &internal.AnyMatcher{Matchers: []expect.Matcher{
 			&internal.EOFMatcher{},
 			&internal.FlushMatcher{},
 		}})
 		return nil
 	})
+	m.strict.read(buf)
+	err = wrapConsoleError(err)
+	m.stats.flushed(began)
+	m.trace.completed(TraceFlush, began, nil, err)
 
 	return err
 }
 
 // ContainsString determines if the emulated terminal's view matches specified string. A "view" takes into account terminal row/columns.
-// Terminal contents are stripped of ANSI escape characters and trimmed.
+// Terminal contents are stripped of ANSI escape characters and trimmed. Rows retained by WithScrollback are searched too.
 func (m *Mimic) ContainsString(str ...string) bool {
 	// note: we don't use go-expect's Regexp matcher here because it can invoke multiple times on the buffer
 	// instead, we Flush which writes all runes to the terminal view, and check regexes against that
 	err := m.Flush()
 	if err != nil {
-		if isDebugEnabled() {
-			_, _ = fmt.Fprintf(os.Stderr, "[Error]: ContainsString: %v\n", err)
-		}
+		m.debug("ContainsString failed to flush", "error", err)
+		m.transcript.record("ContainsString", str, "", err)
 		return false
 	}
 
 	v := Viewer{Mimic: m, StripAnsi: true, Trim: true}
-	contents := v.String()
+	view := v.String()
+	if m.matching.whitespace {
+		view = m.unwrappedView()
+	}
+	contents := m.searchable(view)
 
-	failed := 0
+	failed := make([]string, 0)
 	terminalContents := bytes.NewBufferString(contents)
 
+	matchers := make([]expect.Matcher, 0, len(str))
 	for _, s := range str {
-		matcher := internal.PlainStringMatcher{
-			S: s,
-		}
+		matcher := m.stringMatcher(s)
+		matchers = append(matchers, matcher)
 		if !matcher.Match(terminalContents) {
-			failed += 1
+			failed = append(failed, s)
 		}
 	}
-	return failed == 0
+	m.transcript.record("ContainsString", str, contents, notContained(failed))
+	if len(failed) > 0 {
+		return false
+	}
+	m.strict.consumeContains(matchers)
+	return true
 }
 
 // ContainsPattern determines if the emulated terminal's view contains one or more specified patterns.
-// Patterns are evaluated against formatted terminal contents, stripped of ANSI escape characters and trimmed.
+// Patterns are evaluated against formatted terminal contents, stripped of ANSI escape characters and trimmed. Rows
+// retained by WithScrollback precede the view's rows.
+//
+// ContainsPattern returns false if any pattern is invalid; the error is recorded in the transcript (see
+// WithTranscript). Use CompilePattern to validate a pattern beforehand.
 func (m *Mimic) ContainsPattern(pattern ...string) bool {
-	var regexes []*regexp.Regexp
-	for _, p := range pattern {
-		re := regexp.MustCompile(p)
-		regexes = append(regexes, re)
+	regexes, err := compilePatterns(pattern)
+	if err != nil {
+		m.debug("ContainsPattern received an invalid pattern", "error", err)
+		m.transcript.record("ContainsPattern", pattern, "", err)
+		return false
+	}
+	return m.containsRegexps("ContainsPattern", pattern, regexes)
+}
+
+// ContainsRegexp determines if the emulated terminal's view contains every one of the compiled regular expressions, as
+// Mimic.ContainsPattern does for patterns. Registered {{name}} placeholders aren't expanded, as regex is already
+// compiled. ContainsRegexp returns false if any regex is nil.
+func (m *Mimic) ContainsRegexp(regex ...*regexp.Regexp) bool {
+	criteria, err := regexpCriteria(regex)
+	if err != nil {
+		m.debug("ContainsRegexp received an invalid regexp", "error", err)
+		m.transcript.record("ContainsRegexp", nil, "", err)
+		return false
 	}
+	return m.containsRegexps("ContainsRegexp", criteria, regex)
+}
 
+// containsRegexps determines if the view contains every one of regexes, compiled from criteria, on behalf of operation
+func (m *Mimic) containsRegexps(operation string, criteria []string, regexes []*regexp.Regexp) bool {
 	// note: we don't use go-expect's Regexp matcher here because it can invoke multiple times on the buffer
 	// instead, we Flush which writes all runes to the terminal view, and check regexes against that
 	err := m.Flush()
 	if err != nil {
-		if isDebugEnabled() {
-			_, _ = fmt.Fprintf(os.Stderr, "[Error]: ContainsPattern: %v\n", err)
-		}
+		m.debug(operation+" failed to flush", "error", err)
+		m.transcript.record(operation, criteria, "", err)
 		return false
 	}
 
 	v := Viewer{Mimic: m, StripAnsi: true, Trim: true}
-	contents := v.String()
+	contents := m.searchable(v.String())
 	failed := make([]string, 0)
-	for _, regex := range regexes {
+	for i, regex := range regexes {
 		if !regex.MatchString(contents) {
-			failed = append(failed, regex.String())
+			failed = append(failed, criteria[i])
 		}
 	}
 
-	if len(pattern) > 0 && len(failed) == 0 {
+	m.transcript.record(operation, criteria, contents, notContained(failed))
+	if len(criteria) > 0 && len(failed) == 0 {
+		m.strict.consumeContains(regexpMatchers(regexes))
 		return true
 	}
 
-	if isDebugEnabled() {
-		_, _ = fmt.Fprintf(os.Stderr, "[Error]: ContainsPattern failed on: %v\n", strings.Join(failed, ","))
-	}
+	m.debug(operation+" failed", "patterns", strings.Join(failed, ","))
 
 	return false
 }
 
-// ExpectPattern waits for the emulated terminal's view to contain one or more specified patterns
-func (m *Mimic) ExpectPattern(pattern ...string) error {
-	var regexes []*regexp.Regexp
-	for _, p := range pattern {
-		re := regexp.MustCompile(p)
-		regexes = append(regexes, re)
-	}
-	_, err := m.console.Expect(expect.WithTimeout(m.maxIdleWait), internal.Regexp(regexes...))
-	return err
+// ExpectPattern waits for the emulated terminal's view to contain one or more specified patterns, returning
+// the result of the first pattern to match. See Mimic.ExpectPatternContext.
+func (m *Mimic) ExpectPattern(pattern ...string) (MatchResult, error) {
+	return m.ExpectPatternContext(context.Background(), pattern...)
 }
 
 // ExpectString waits for the emulated terminal's view to contain one or more specified strings
 func (m *Mimic) ExpectString(str ...string) error {
-	_, err := m.console.Expect(expect.WithTimeout(m.maxIdleWait), internal.String(str...))
-	return err
+	return m.ExpectStringContext(context.Background(), str...)
+}
+
+// stringMatcher matches s as plain text, or as a pattern if s contains registered {{name}} placeholders and they're
+// enabled via WithPlaceholders
+func (m *Mimic) stringMatcher(s string) expect.Matcher {
+	if !m.matching.placeholders {
+		return &internal.PlainStringMatcher{S: s, Contains: m.matching.containsFunc()}
+	}
+	if re, ok := literalPattern(s); ok {
+		if m.matching.caseInsensitive {
+			if folded, err := compiledPatterns.compile("(?i)" + re.String()); err == nil {
+				re = folded
+			}
+		}
+		return &internal.RegexpMatcher{Re: re}
+	}
+	return &internal.PlainStringMatcher{S: s, Contains: m.matching.containsFunc()}
 }
 
 // NoMoreExpectations signals the underlying buffer to finish writing bytes to the underlying pseudo-terminal.
@@ -281,62 +486,184 @@ func (m *Mimic) NoMoreExpectations() error {
 	// We flush here because ExpectEOF can sometimes "hang" if there are no Expect interactions prior to calling it.
 	err := m.Flush()
 	if err != nil {
-		if isDebugEnabled() {
-			_, _ = fmt.Fprintf(os.Stderr, "[Error]: NoMoreExpectations: %v", err)
-		}
+		m.debug("NoMoreExpectations failed to flush", "error", err)
 		return err
 	}
 
-	_, err = m.console.ExpectEOF()
-	return err
+	m.reading.Lock()
+	buf, err := m.console.ExpectEOF()
+	m.endRead()
+	m.reading.Unlock()
+	m.strict.read(buf)
+	if unexpected := m.strict.unexpected(); unexpected != nil {
+		return unexpected
+	}
+	return wrapConsoleError(err)
 }
 
-// Tty provides the underlying tty required for interacting with this console
+// Tty provides the underlying tty required for interacting with this console, or nil if the terminal is emulated in
+// memory (see WithInMemoryPty). See Mimic.TtyFile.
 func (m *Mimic) Tty() *os.File {
-	return m.console.Tty()
+	tty, _ := m.TtyFile()
+	return tty
+}
+
+// TtyFile provides the underlying tty required for interacting with this console, or ErrNoPty if the terminal is
+// emulated in memory (see WithInMemoryPty)
+func (m *Mimic) TtyFile() (*os.File, error) {
+	c, ok := m.console.(ptyConsole)
+	if !ok {
+		return nil, ErrNoPty
+	}
+	return c.Tty(), nil
 }
 
-// Fd file descriptor of underlying pty.
+// Device provides the program's side of the emulated terminal: the program under test reads input from it and writes
+// output to it. This is the tty (see Mimic.Tty), unless the terminal is emulated in memory (see WithInMemoryPty).
+func (m *Mimic) Device() io.ReadWriter {
+	return m.device
+}
+
+// Fd file descriptor of underlying pty. If the terminal is emulated in memory (see WithInMemoryPty), Fd is invalid, as
+// os.File's is once closed; see Mimic.PtyFd.
 func (m *Mimic) Fd() uintptr {
-	return m.console.Fd()
+	fd, err := m.PtyFd()
+	if err != nil {
+		return ^uintptr(0)
+	}
+	return fd
 }
 
-// NewMimic creates a Mimic, which emulates a pseudo terminal device and provides
-// utility functions for inputs/assertions/expectations upon it
-func NewMimic(opts ...Option) (*Mimic, error) {
-	pty, tty, err := creakpty.Open()
+// PtyFd provides the file descriptor of the underlying pty, or ErrNoPty if the terminal is emulated in memory (see
+// WithInMemoryPty)
+func (m *Mimic) PtyFd() (uintptr, error) {
+	c, ok := m.console.(ptyConsole)
+	if !ok {
+		return 0, ErrNoPty
+	}
+	return c.Fd(), nil
+}
+
+// NewMimicWithContext creates a Mimic as NewMimic does, bound to the lifetime of ctx. Once ctx is done, the Mimic is
+// closed: the pty pair is closed and pending expectations return ErrClosed. Closing the Mimic first releases its
+// binding to ctx.
+func NewMimicWithContext(ctx context.Context, opts ...Option) (*Mimic, error) {
+	m, err := NewMimic(opts...)
 	if err != nil {
 		return nil, err
 	}
 
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = m.Close()
+		case <-m.closed:
+		}
+	}()
+	return m, nil
+}
+
+// NewMimic creates a Mimic, which emulates a pseudo terminal device and provides
+// utility functions for inputs/assertions/expectations upon it
+func NewMimic(opts ...Option) (*Mimic, error) {
 	o := &mimicOpt{
 		w:              io.Discard,
+		logOutput:      os.Stderr,
 		columns:        DefaultColumns,
 		rows:           DefaultRows,
 		maxIdleTimeout: DefaultIdleTimeout,
 		flushTimeout:   DefaultFlushTimeout,
 		idleDuration:   DefaultIdleDuration,
+		idlePoll:       expectPollInterval,
+		diffColor:      diffColorDefault(),
+		detachKey:      DefaultDetachKey,
 	}
 
 	for _, opt := range opts {
 		opt(o)
 	}
 
-	consoleOptions := make([]expect.ConsoleOpt, 0)
+	if o.logger == nil {
+		o.logger = writerLogger{w: o.logOutput}
+	}
+	var consoleLogger *log.Logger
+	if o.logger.debugEnabled() {
+		consoleLogger = log.New(consoleLogWriter{logger: o.logger}, "", 0)
+	}
+
+	stdIn := make([]io.Reader, 0)
+	// the terminal writes replies to queries (e.g. of the cursor position) as input to the program
+	var replies io.Writer
+	var memory *memoryConsole
+	var pty, tty *os.File
+	if o.inMemory || !ptySupported {
+		memory = newMemoryConsole(consoleLogger)
+		replies = memory.input
+	} else {
+		var err error
+		pty, tty, err = openPty()
+		if err != nil {
+			return nil, err
+		}
+		replies = tty
+		stdIn = append(stdIn, pty)
+	}
 
+	responses := o.queryResponses.withDefaults()
+	queries := &queryHandlers{}
 	terminal := vt10x.New(
-		vt10x.WithWriter(tty),
+		vt10x.WithWriter(&terminalReplies{w: replies, cursorPosition: responses.CursorPosition, handlers: queries}),
 		vt10x.WithSize(o.columns, o.rows),
 	)
 
-	stdIn := make([]io.Reader, 0)
-	stdIn = append(stdIn, pty)
 	if o.in != nil {
 		stdIn = append(stdIn, o.in)
 	}
 
+	// Output read by the console is written to each tap of stdOut in order: the throttle delays output before any other
+	// tap observes it, and taps following the view observe output which has already been rendered into it. A failed
+	// write ends the console's read, so taps other than the user's writers (o.w and os.Stdout) never fail.
+	modes := &modeTracker{}
 	stdOut := make([]io.Writer, 0)
-	stdOut = append(stdOut, terminal)
+	if o.throttle.enabled() {
+		stdOut = append(stdOut, newThrottle(o.throttle))
+	}
+	stdOut = append(stdOut, modes)
+	var rec *recording
+	if o.recording != nil {
+		rec = newRecording(o.recording.format(o.recording.w), o.rows, o.columns)
+		stdOut = append(stdOut, rec)
+	}
+	var trace *tracer
+	if o.trace != nil {
+		trace = newTracer(o.trace)
+		stdOut = append(stdOut, trace)
+	}
+	var hist *history
+	if o.history {
+		hist = &history{}
+		stdOut = append(stdOut, hist)
+	}
+	var view io.Writer = terminal
+	if o.grow {
+		view = &growingWriter{terminal: terminal, step: o.rows, maxRows: o.maxRows}
+	}
+	var scroll *scrollback
+	if o.scrollback > 0 {
+		scroll = &scrollback{terminal: terminal, w: view, max: o.scrollback}
+		view = scroll
+	}
+	stdOut = append(stdOut, newViewWriter(terminal, view))
+	events := &events{terminal: terminal}
+	stats := &stats{}
+	idle := &idleWatchers{}
+	passthrough := &passthrough{}
+	bells := &bells{}
+	links := &hyperlinks{terminal: terminal}
+	clip := &clipboard{}
+	shape := &cursorShape{}
+	answers := &responder{replies: replies, responses: responses, handlers: queries}
+	stdOut = append(stdOut, events, stats, idle, passthrough, bells, links, clip, shape, answers)
 	if o.w != nil {
 		stdOut = append(stdOut, o.w)
 	}
@@ -346,40 +673,84 @@ func NewMimic(opts ...Option) (*Mimic, error) {
 		stdOut = append(stdOut, os.Stdout)
 	}
 
-	consoleOptions = append(consoleOptions, expect.WithStdin(stdIn...))
-	consoleOptions = append(consoleOptions, expect.WithStdout(stdOut...))
-	consoleOptions = append(consoleOptions, expect.WithCloser(pty, tty))
-
-	if isDebugEnabled() {
-		consoleOptions = append(consoleOptions, expect.WithLogger(log.New(os.Stderr, "mimic: ", 0)))
+	var c console
+	var device io.ReadWriteCloser
+	if memory != nil {
+		memory.start(stdIn, stdOut)
+		c, device = memory, memory.device
+	} else {
+		var err error
+		c, device, err = newPtyConsole(stdIn, stdOut, pty, tty, consoleLogger)
+		if err != nil {
+			return nil, err
+		}
 	}
-
-	c, err := expect.NewConsole(consoleOptions...)
-
-	if err != nil {
-		return nil, err
+	if o.faults.enabled() {
+		device = &faultDevice{ReadWriteCloser: device, faults: o.faults}
 	}
 
 	m := Mimic{
 		console:      c,
+		device:       device,
+		reading:      &sync.Mutex{},
+		closed:       make(chan struct{}),
+		closeOnce:    &sync.Once{},
+		consoleOnce:  &sync.Once{},
 		terminal:     terminal,
 		maxIdleWait:  o.maxIdleTimeout,
 		idleDuration: o.idleDuration,
+		idlePoll:     o.idlePoll,
+		idle:         idle,
+		idleStrategy: o.idleStrategy,
+		deadline:     o.deadline,
+		env:          o.env,
 		flushTimeout: o.flushTimeout,
+		resize:       &resizeHandlers{},
+		observers:    &observers{},
+		events:       events,
+		stats:        stats,
+		modes:        modes,
+		recording:    rec,
+		trace:        trace,
+		logger:       o.logger,
+		matching:     o.matching,
+		diffColor:    o.diffColor,
+		detachKey:    o.detachKey,
+		passthrough:  passthrough,
+		faults:       o.faults,
+		maxBuffer:    o.maxBuffer,
+		history:      hist,
+		scrollback:   scroll,
+		bells:        bells,
+		hyperlinks:   links,
+		clipboard:    clip,
+		queries:      queries,
+		cursorShape:  shape,
+	}
+	if o.transcript {
+		m.transcript = &transcript{}
+	}
+	if o.strict {
+		m.strict = &strictTracker{}
 	}
 
 	m.Experimental = exp(m)
+	if rec != nil {
+		m.OnResize(rec.resize)
+	}
 
 	return &m, nil
 }
 
-func isDebugEnabled() bool {
-	if val, ok := os.LookupEnv("DEBUG"); ok {
-		debug, _ := strconv.ParseBool(val)
-		return debug
-	}
+// newViewWriter renders output into terminal via view, which writes to terminal: colon-separated SGR parameters are
+// normalized, and wide runes are given two columns
+func newViewWriter(terminal vt10x.Terminal, view io.Writer) io.Writer {
+	return &sgrNormalizer{w: &wideRunes{terminal: terminal, w: view}}
+}
 
-	return false
+// debug writes a diagnostic message, followed by alternating keys and values, to the configured logger
+func (m *Mimic) debug(msg string, args ...interface{}) {
+	m.logger.debug(msg, args...)
 }
 
 // for file-based Stdout
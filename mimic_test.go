@@ -2,8 +2,13 @@ package mimic
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -63,6 +68,11 @@ func TestMimic_ContainsPattern(t *testing.T) {
 		{name: "escapes ansi complex", contents: "\x1b[0m\x1b[4m\x1b[42m\x1b[31mfoo\x1b[39m\x1b[49m\x1b[24mfoo\x1b[0m", pattern: []string{
 			"^foofoo$",
 		}, want: true},
+
+		{name: "invalid pattern doesn't match", contents: "Hello, World!", pattern: []string{
+			"Hello",
+			"(World",
+		}, want: false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -110,6 +120,19 @@ func TestMimic_ExpectString(t *testing.T) {
 	}
 }
 
+func TestMimic_ContainsRegexp(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(50 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.WriteString("Hello, World!")
+	assert.NoError(t, err)
+
+	assert.True(t, m.ContainsRegexp(regexp.MustCompile(`[hH]ello`), regexp.MustCompile(`W\w+d`)))
+	assert.False(t, m.ContainsRegexp(regexp.MustCompile(`puppies`)))
+	assert.False(t, m.ContainsRegexp(regexp.MustCompile(`Hello`), nil), "a nil regexp shouldn't panic")
+}
+
 func TestMimic_ExpectPattern(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -142,7 +165,160 @@ func TestMimic_ExpectPattern(t *testing.T) {
 			_, err = m.WriteString(tt.contents)
 			assert.NoError(t, err)
 
-			tt.wantErr(t, m.ExpectPattern(tt.pattern...), fmt.Sprintf("ExpectPattern(%v)", tt.pattern))
+			_, err = m.ExpectPattern(tt.pattern...)
+			tt.wantErr(t, err, fmt.Sprintf("ExpectPattern(%v)", tt.pattern))
 		})
 	}
 }
+
+func TestMimic_concurrentExpectAndWrite(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, m.ExpectString("line 19"))
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			_, _ = fmt.Fprintf(m.Tty(), "line %d\r\n", i)
+			_, _ = m.WriteString("x")
+			m.Cursor()
+			m.ContainsString("line")
+		}
+	}()
+	wg.Wait()
+}
+
+func TestMimic_concurrentExpectPattern(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := m.ExpectPattern(`ready\s`)
+			assert.NoError(t, err)
+		}()
+	}
+
+	// expectations are serialized, so each waits for its own occurrence of the output
+	for i := 0; i < 4; i++ {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = m.Tty().WriteString("ready\r\n")
+	}
+	wg.Wait()
+}
+
+func TestMimic_Close_cancelsPendingExpect(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(10 * time.Second))
+	assert.NoError(t, err)
+
+	result := make(chan error, 1)
+	go func() {
+		result <- m.ExpectString("never written")
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	started := time.Now()
+	assert.NoError(t, m.Close())
+
+	select {
+	case err := <-result:
+		assert.ErrorIs(t, err, ErrClosed)
+		assert.Less(t, time.Since(started), time.Second)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expectation was not cancelled by Close")
+	}
+
+	assert.ErrorIs(t, m.ExpectString("after close"), ErrClosed)
+}
+
+func TestMimic_Shutdown_drainsOutput(t *testing.T) {
+	m, err := NewMimic(WithSize(5, 40))
+	assert.NoError(t, err)
+
+	_, err = m.Tty().WriteString("written just before close")
+	assert.NoError(t, err)
+
+	assert.NoError(t, m.CloseWithTimeout(time.Second))
+	v := Viewer{Mimic: m, StripAnsi: true, Trim: true}
+	assert.Equal(t, "written just before close", v.String())
+}
+
+func TestMimic_Shutdown_boundedByContext(t *testing.T) {
+	m, err := NewMimic()
+	assert.NoError(t, err)
+
+	// an additional writer holds the terminal open, as an attached process would
+	held, err := os.OpenFile(m.Tty().Name(), os.O_WRONLY, 0)
+	assert.NoError(t, err)
+	defer held.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, m.Shutdown(ctx), context.DeadlineExceeded)
+	assert.ErrorIs(t, m.ExpectString("anything"), ErrClosed)
+}
+
+func TestMimic_Close_idempotent(t *testing.T) {
+	m, err := NewMimic()
+	assert.NoError(t, err)
+	assert.False(t, m.IsClosed())
+
+	assert.NoError(t, m.Close())
+	assert.True(t, m.IsClosed())
+	assert.NoError(t, m.Close(), "closing again should not report an error")
+	assert.NoError(t, m.CloseWithTimeout(time.Second))
+	assert.True(t, m.With(WithCallTimeout(time.Second)).IsClosed(), "derived mimics share the closed state")
+}
+
+func TestNewMimicWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	m, err := NewMimicWithContext(ctx, WithIdleTimeout(5*time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	expectation := make(chan error, 1)
+	go func() {
+		expectation <- m.ExpectString("never")
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-expectation:
+		assert.ErrorIs(t, err, ErrClosed)
+	case <-time.After(time.Second):
+		t.Fatal("cancelling the context should abort pending expectations")
+	}
+	assert.True(t, m.IsClosed())
+}
+
+func TestWithMaxBuffer(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithSize(5, 40), WithMaxBuffer(64), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	go func() {
+		for i := 0; i < 100; i++ {
+			_, _ = fmt.Fprintf(m.Device(), "log line %d\r\n", i)
+		}
+		_, _ = fmt.Fprint(m.Device(), "ready")
+	}()
+	assert.NoError(t, m.ExpectString("ready"), "criteria within the bound should match")
+	assert.True(t, m.ContainsString("log line 99", "ready"), "the view should remain accurate")
+
+	_, _ = fmt.Fprint(m.Device(), strings.Repeat("x", 200))
+	var patternErr PatternError
+	assert.ErrorAs(t, m.ExpectString("missing"), &patternErr)
+	assert.LessOrEqual(t, len(patternErr.Contents), 64, "reported output should be bounded")
+}
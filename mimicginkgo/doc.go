@@ -0,0 +1,18 @@
+/*
+Package mimicginkgo integrates mimic with Ginkgo's spec lifecycle, analogous to the testify-based suite package.
+
+Each spec receives an isolated console which is closed automatically via DeferCleanup. When a spec fails, the
+formatted terminal view is attached to the spec's report so that it appears alongside the failure:
+
+	var _ = Describe("survey prompts", func() {
+		console := mimicginkgo.ConsoleForEach(mimic.WithSize(24, 80))
+
+		It("asks for a name", func() {
+			m := console.Mimic()
+			// …
+		})
+	})
+
+Ginkgo doesn't support js/wasm, so the package is empty there.
+*/
+package mimicginkgo
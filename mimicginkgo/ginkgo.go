@@ -0,0 +1,52 @@
+//go:build !js
+
+package mimicginkgo
+
+import (
+	"github.com/jimschubert/mimic"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+)
+
+// ScreenReportEntry is the name of the report entry holding the terminal view of a failed spec
+const ScreenReportEntry = "mimic screen"
+
+// New constructs a Mimic for the current spec, failing the spec if construction fails. The Mimic is closed when the
+// spec completes, after the terminal view is attached to the spec's report if the spec failed.
+// New must be invoked from within a setup or subject node, such as BeforeEach or It.
+func New(opts ...mimic.Option) *mimic.Mimic {
+	ginkgo.GinkgoHelper()
+
+	m, err := mimic.NewMimic(opts...)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred(), "unable to construct mimic")
+
+	ginkgo.DeferCleanup(func() {
+		if ginkgo.CurrentSpecReport().Failed() {
+			v := mimic.Viewer{Mimic: m, StripAnsi: true, Trim: true}
+			ginkgo.AddReportEntry(ScreenReportEntry, v.String(), ginkgo.ReportEntryVisibilityFailureOrVerbose)
+		}
+		_ = m.Close()
+	})
+
+	return m
+}
+
+// Console holds the Mimic for the currently running spec. See ConsoleForEach.
+type Console struct {
+	current *mimic.Mimic
+}
+
+// Mimic returns the Mimic constructed for the currently running spec
+func (c *Console) Mimic() *mimic.Mimic {
+	return c.current
+}
+
+// ConsoleForEach registers a BeforeEach which constructs an isolated Mimic for every spec in the enclosing container.
+// ConsoleForEach must be invoked from within a container node, such as Describe or Context.
+func ConsoleForEach(opts ...mimic.Option) *Console {
+	c := &Console{}
+	ginkgo.BeforeEach(func() {
+		c.current = New(opts...)
+	})
+	return c
+}
@@ -0,0 +1,39 @@
+//go:build !js
+
+package mimicginkgo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jimschubert/mimic"
+	"github.com/jimschubert/mimic/mimicmatchers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestMimicGinkgo(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "mimicginkgo")
+}
+
+var _ = Describe("ConsoleForEach", Ordered, func() {
+	console := ConsoleForEach(mimic.WithSize(10, 40), mimic.WithIdleTimeout(500*time.Millisecond))
+	var previous *mimic.Mimic
+
+	It("provides a console to each spec", func() {
+		m := console.Mimic()
+		Expect(m).NotTo(BeNil())
+
+		_, err := m.Tty().WriteString("Hello, Ginkgo")
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(m).Should(mimicmatchers.ContainOnScreen("Hello, Ginkgo"))
+		previous = m
+	})
+
+	It("isolates consoles between specs", func() {
+		m := console.Mimic()
+		Expect(m).NotTo(BeIdenticalTo(previous))
+		Expect(m).NotTo(mimicmatchers.ContainOnScreen("Hello, Ginkgo"))
+	})
+})
@@ -0,0 +1,213 @@
+/*
+Package mimicmatchers provides Gomega matchers for asserting on mimic values.
+
+Matchers which inspect the terminal view (ContainOnScreen, MatchScreenPattern) re-evaluate the view on each invocation,
+so they may be polled asynchronously via Eventually and Consistently:
+
+	Eventually(console).WithTimeout(time.Second).Should(ContainOnScreen("What is your name?"))
+
+EventuallyExpect instead performs a stream expectation, which consumes output; it blocks on its own, so use it with
+Expect rather than Eventually:
+
+	Expect(console).To(EventuallyExpect("What is your name?"))
+*/
+package mimicmatchers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jimschubert/mimic"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+// ContainOnScreen succeeds if actual is a *mimic.Mimic whose view contains all expected strings.
+// See mimic.Mimic.ContainsString.
+func ContainOnScreen(expected ...string) types.GomegaMatcher {
+	return &containOnScreenMatcher{expected: expected}
+}
+
+// MatchScreenPattern succeeds if actual is a *mimic.Mimic whose view matches all expected patterns.
+// See mimic.Mimic.ContainsPattern.
+func MatchScreenPattern(patterns ...string) types.GomegaMatcher {
+	return &matchScreenPatternMatcher{patterns: patterns}
+}
+
+// ContainView succeeds if actual is a *mimic.Mimic whose view contains expected, which may span multiple rows.
+// Trailing whitespace of each row in the view and in expected is ignored, so a layout can be asserted as it appears:
+//
+//	Expect(console).To(ContainView("Name: Tom\nAge:  20"))
+func ContainView(expected string) types.GomegaMatcher {
+	return &containViewMatcher{expected: expected}
+}
+
+// EventuallyExpect succeeds if actual is a *mimic.Mimic whose output contains all expected strings before its idle
+// timeout. See mimic.Mimic.ExpectString.
+func EventuallyExpect(expected ...string) types.GomegaMatcher {
+	return &eventuallyExpectMatcher{expected: expected}
+}
+
+// HaveExitedWith succeeds if actual reports the expected exit code via an ExitCode() int method,
+// such as *os.ProcessState or *exec.ExitError.
+func HaveExitedWith(code int) types.GomegaMatcher {
+	return &haveExitedWithMatcher{expected: code}
+}
+
+type containOnScreenMatcher struct {
+	expected []string
+	screen   string
+}
+
+func (c *containOnScreenMatcher) Match(actual interface{}) (bool, error) {
+	m, err := toMimic("ContainOnScreen", actual)
+	if err != nil {
+		return false, err
+	}
+
+	success := m.ContainsString(c.expected...)
+	c.screen = screen(m)
+	return success, nil
+}
+
+func (c *containOnScreenMatcher) FailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected screen\n%s\nto contain\n%s", c.screen, format.Object(c.expected, 1))
+}
+
+func (c *containOnScreenMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected screen\n%s\nnot to contain\n%s", c.screen, format.Object(c.expected, 1))
+}
+
+type matchScreenPatternMatcher struct {
+	patterns []string
+	screen   string
+}
+
+func (p *matchScreenPatternMatcher) Match(actual interface{}) (bool, error) {
+	m, err := toMimic("MatchScreenPattern", actual)
+	if err != nil {
+		return false, err
+	}
+
+	for _, pattern := range p.patterns {
+		if _, err := mimic.CompilePattern(pattern); err != nil {
+			return false, fmt.Errorf("MatchScreenPattern received an invalid pattern: %w", err)
+		}
+	}
+
+	success := m.ContainsPattern(p.patterns...)
+	p.screen = screen(m)
+	return success, nil
+}
+
+func (p *matchScreenPatternMatcher) FailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected screen\n%s\nto match patterns\n%s", p.screen, format.Object(p.patterns, 1))
+}
+
+func (p *matchScreenPatternMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected screen\n%s\nnot to match patterns\n%s", p.screen, format.Object(p.patterns, 1))
+}
+
+type containViewMatcher struct {
+	expected string
+	screen   string
+}
+
+func (c *containViewMatcher) Match(actual interface{}) (bool, error) {
+	m, err := toMimic("ContainView", actual)
+	if err != nil {
+		return false, err
+	}
+
+	_ = m.Flush()
+	v := mimic.Viewer{Mimic: m, StripAnsi: true, Trim: true}
+	c.screen = screen(m)
+	return strings.Contains(strings.Join(v.Lines(), "\n"), trimLines(c.expected)), nil
+}
+
+func (c *containViewMatcher) FailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected screen\n%s\nto contain view\n%s", c.screen, format.Object(c.expected, 1))
+}
+
+func (c *containViewMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected screen\n%s\nnot to contain view\n%s", c.screen, format.Object(c.expected, 1))
+}
+
+type eventuallyExpectMatcher struct {
+	expected []string
+	err      error
+	screen   string
+}
+
+func (e *eventuallyExpectMatcher) Match(actual interface{}) (bool, error) {
+	m, err := toMimic("EventuallyExpect", actual)
+	if err != nil {
+		return false, err
+	}
+
+	e.err = m.ExpectString(e.expected...)
+	e.screen = screen(m)
+	return e.err == nil, nil
+}
+
+func (e *eventuallyExpectMatcher) FailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected output to contain\n%s\nbut the expectation failed: %v\nScreen:\n%s", format.Object(e.expected, 1), e.err, e.screen)
+}
+
+func (e *eventuallyExpectMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected output not to contain\n%s\nScreen:\n%s", format.Object(e.expected, 1), e.screen)
+}
+
+type exitCoder interface {
+	ExitCode() int
+}
+
+type haveExitedWithMatcher struct {
+	expected int
+	actual   int
+}
+
+func (h *haveExitedWithMatcher) Match(actual interface{}) (bool, error) {
+	process, ok := actual.(exitCoder)
+	if !ok || process == nil {
+		return false, fmt.Errorf("HaveExitedWith matcher expects a value with an ExitCode() int method. Got:\n%s", format.Object(actual, 1))
+	}
+
+	h.actual = process.ExitCode()
+	return h.actual == h.expected, nil
+}
+
+func (h *haveExitedWithMatcher) FailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected process to have exited with code %d, but it exited with code %d", h.expected, h.actual)
+}
+
+func (h *haveExitedWithMatcher) NegatedFailureMessage(_ interface{}) string {
+	return fmt.Sprintf("Expected process not to have exited with code %d", h.expected)
+}
+
+func toMimic(matcher string, actual interface{}) (*mimic.Mimic, error) {
+	m, ok := actual.(*mimic.Mimic)
+	if !ok || m == nil {
+		return nil, fmt.Errorf("%s matcher expects a *mimic.Mimic. Got:\n%s", matcher, format.Object(actual, 1))
+	}
+	return m, nil
+}
+
+// screen renders the formatted view, indented for inclusion in a failure message
+func screen(m *mimic.Mimic) string {
+	v := mimic.Viewer{Mimic: m, StripAnsi: true, Trim: true}
+	lines := strings.Split(v.String(), "\n")
+	for i, line := range lines {
+		lines[i] = format.Indent + "| " + strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// trimLines removes trailing whitespace from each line of s
+func trimLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,103 @@
+package mimicmatchers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jimschubert/mimic"
+	. "github.com/onsi/gomega"
+)
+
+type exitCode int
+
+func (e exitCode) ExitCode() int {
+	return int(e)
+}
+
+func TestContainOnScreen(t *testing.T) {
+	g := NewWithT(t)
+	m, err := mimic.NewMimic()
+	g.Expect(err).NotTo(HaveOccurred())
+	defer m.Close()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_, _ = m.Tty().WriteString("What is your name?")
+	}()
+
+	g.Eventually(m).WithTimeout(time.Second).Should(ContainOnScreen("What is your name?"))
+	g.Expect(m).NotTo(ContainOnScreen("How old are you?"))
+
+	matcher := ContainOnScreen("missing")
+	success, err := matcher.Match(m)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(success).To(BeFalse())
+	g.Expect(matcher.FailureMessage(m)).To(ContainSubstring("| What is your name?"))
+
+	_, err = matcher.Match("not a mimic")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestMatchScreenPattern(t *testing.T) {
+	g := NewWithT(t)
+	m, err := mimic.NewMimic()
+	g.Expect(err).NotTo(HaveOccurred())
+	defer m.Close()
+
+	_, _ = m.Tty().WriteString("Version 1.2.3")
+
+	g.Eventually(m).WithTimeout(time.Second).Should(MatchScreenPattern(`Version \d+\.\d+\.\d+`))
+
+	_, err = MatchScreenPattern(`(`).Match(m)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestContainView(t *testing.T) {
+	g := NewWithT(t)
+	m, err := mimic.NewMimic(mimic.WithSize(5, 30))
+	g.Expect(err).NotTo(HaveOccurred())
+	defer m.Close()
+
+	_, _ = m.Tty().WriteString("Summary\r\nName: Tom   \r\nAge:  20")
+
+	g.Expect(m).To(ContainView("Name: Tom\nAge:  20"))
+	g.Expect(m).To(ContainView("Summary  \nName: Tom"), "trailing whitespace should be ignored")
+	g.Expect(m).NotTo(ContainView("Name: Tom\nAge:  21"))
+	g.Expect(m).NotTo(ContainView("Summary\nAge:  20"), "rows should be contiguous")
+
+	matcher := ContainView("missing")
+	_, _ = matcher.Match(m)
+	g.Expect(matcher.FailureMessage(m)).To(ContainSubstring("| Name: Tom"))
+}
+
+func TestEventuallyExpect(t *testing.T) {
+	g := NewWithT(t)
+	m, err := mimic.NewMimic(mimic.WithIdleTimeout(200 * time.Millisecond))
+	g.Expect(err).NotTo(HaveOccurred())
+	defer m.Close()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_, _ = m.Tty().WriteString("Done.")
+	}()
+
+	g.Expect(m).To(EventuallyExpect("Done."))
+
+	matcher := EventuallyExpect("never")
+	success, err := matcher.Match(m)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(success).To(BeFalse())
+	g.Expect(matcher.FailureMessage(m)).To(ContainSubstring("but the expectation failed"))
+
+	_, err = matcher.Match(nil)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestHaveExitedWith(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(exitCode(3)).To(HaveExitedWith(3))
+	g.Expect(exitCode(0)).NotTo(HaveExitedWith(3))
+
+	_, err := HaveExitedWith(0).Match("not a process")
+	g.Expect(err).To(HaveOccurred())
+}
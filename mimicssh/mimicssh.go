@@ -0,0 +1,200 @@
+/*
+Package mimicssh adapts a mimic.Mimic to SSH session channels from golang.org/x/crypto/ssh, allowing interactive SSH
+session handlers to be tested end to end against the emulated terminal.
+
+A Session is an ssh.Channel whose data stream is the emulated terminal: the handler reads input sent via the Mimic
+(e.g. Mimic.WriteString), and output written by the handler is rendered into the Mimic's view. Requests which a client
+would send, such as "pty-req" and "shell", are delivered to the handler via Session.Requests:
+
+	session := mimicssh.NewSession(console)
+	session.RequestPty("xterm")
+	session.RequestShell()
+	go handleSession(session, session.Requests())
+
+	_ = console.ExpectString("Password:")
+	_, _ = console.WriteString("hunter2\r")
+	_ = console.ExpectString("Welcome")
+
+SSH clients with interactive prompts (e.g. for passwords or host key confirmation) read from and write to their local
+terminal, so they are tested by using Mimic.Stdio (or Mimic.Device) as their standard streams rather than a Session.
+*/
+package mimicssh
+
+import (
+	"io"
+	"sync"
+
+	"github.com/jimschubert/mimic"
+	"golang.org/x/crypto/ssh"
+)
+
+// requestBuffer is the capacity of the channel returned by Session.Requests
+const requestBuffer = 16
+
+// Session is an ssh.Channel for a session, backed by a Mimic. See NewSession.
+type Session struct {
+	m        *mimic.Mimic
+	requests chan *ssh.Request
+
+	mu         sync.Mutex
+	closed     bool
+	writeEOF   bool
+	sent       []Request
+	exitStatus *int
+}
+
+// Request is a channel request sent by the handler of a Session, via SendRequest
+type Request struct {
+	Type      string
+	WantReply bool
+	Payload   []byte
+}
+
+// NewSession creates a session channel whose data stream is the emulated terminal of m. Resizes of m (see
+// Mimic.Resize) are delivered to the handler as "window-change" requests.
+func NewSession(m *mimic.Mimic) *Session {
+	s := &Session{m: m, requests: make(chan *ssh.Request, requestBuffer)}
+	m.OnResize(func(rows, columns int) {
+		s.request("window-change", ssh.Marshal(windowChange{Columns: uint32(columns), Rows: uint32(rows)}))
+	})
+	return s
+}
+
+type ptyRequest struct {
+	Term     string
+	Columns  uint32
+	Rows     uint32
+	Width    uint32
+	Height   uint32
+	Modelist string
+}
+
+type windowChange struct {
+	Columns uint32
+	Rows    uint32
+	Width   uint32
+	Height  uint32
+}
+
+type execRequest struct {
+	Command string
+}
+
+type exitStatus struct {
+	Status uint32
+}
+
+// Requests delivers requests sent by the client side of the session, e.g. via RequestPty, to the handler. It is closed
+// when the session is closed.
+func (s *Session) Requests() <-chan *ssh.Request {
+	return s.requests
+}
+
+// RequestPty sends a "pty-req" request for a terminal of type term, sized as the emulated terminal
+func (s *Session) RequestPty(term string) {
+	rows, columns := s.m.Size()
+	s.request("pty-req", ssh.Marshal(ptyRequest{Term: term, Columns: uint32(columns), Rows: uint32(rows)}))
+}
+
+// RequestShell sends a "shell" request
+func (s *Session) RequestShell() {
+	s.request("shell", nil)
+}
+
+// RequestExec sends an "exec" request for command
+func (s *Session) RequestExec(command string) {
+	s.request("exec", ssh.Marshal(execRequest{Command: command}))
+}
+
+// request delivers a request to the handler. Requests don't want replies, as replying requires a connection.
+func (s *Session) request(name string, payload []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	select {
+	case s.requests <- &ssh.Request{Type: name, Payload: payload}:
+	default:
+	}
+}
+
+// Read reads input sent to the emulated terminal
+func (s *Session) Read(data []byte) (int, error) {
+	return s.m.Device().Read(data)
+}
+
+// Write writes output to the emulated terminal
+func (s *Session) Write(data []byte) (int, error) {
+	s.mu.Lock()
+	eof := s.closed || s.writeEOF
+	s.mu.Unlock()
+	if eof {
+		return 0, io.EOF
+	}
+	return s.m.Device().Write(data)
+}
+
+// Close ends the session. The Mimic remains open, so that its view may be inspected.
+func (s *Session) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.requests)
+	}
+	return nil
+}
+
+// CloseWrite ends the handler's output; later writes fail with io.EOF
+func (s *Session) CloseWrite() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writeEOF = true
+	return nil
+}
+
+// SendRequest records a request sent by the handler, e.g. "exit-status". Requests wanting a reply are acknowledged.
+func (s *Session) SendRequest(name string, wantReply bool, payload []byte) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false, io.EOF
+	}
+	s.sent = append(s.sent, Request{Type: name, WantReply: wantReply, Payload: payload})
+	if name == "exit-status" {
+		var status exitStatus
+		if err := ssh.Unmarshal(payload, &status); err == nil {
+			code := int(status.Status)
+			s.exitStatus = &code
+		}
+	}
+	return wantReply, nil
+}
+
+// Stderr writes to the emulated terminal, as a pty session's standard error does
+func (s *Session) Stderr() io.ReadWriter {
+	return s
+}
+
+// SentRequests returns the requests sent by the handler, in order
+func (s *Session) SentRequests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sent := make([]Request, len(s.sent))
+	copy(sent, s.sent)
+	return sent
+}
+
+// ExitStatus returns the status sent by the handler via an "exit-status" request, if any
+func (s *Session) ExitStatus() (status int, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.exitStatus == nil {
+		return 0, false
+	}
+	return *s.exitStatus, true
+}
+
+// compile-time contract
+var _ ssh.Channel = (*Session)(nil)
@@ -0,0 +1,77 @@
+package mimicssh
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jimschubert/mimic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// handleSession is a minimal interactive session handler, as an SSH server would implement
+func handleSession(channel ssh.Channel, requests <-chan *ssh.Request, events chan<- string) {
+	defer channel.Close()
+	for req := range requests {
+		switch req.Type {
+		case "pty-req":
+			var pty ptyRequest
+			_ = ssh.Unmarshal(req.Payload, &pty)
+			events <- fmt.Sprintf("pty %s %dx%d", pty.Term, pty.Rows, pty.Columns)
+		case "window-change":
+			var change windowChange
+			_ = ssh.Unmarshal(req.Payload, &change)
+			events <- fmt.Sprintf("resize %dx%d", change.Rows, change.Columns)
+		case "shell":
+			_, _ = fmt.Fprint(channel, "Password: ")
+			password, _ := bufio.NewReader(channel).ReadString('\n')
+			if strings.TrimSpace(password) == "hunter2" {
+				_, _ = fmt.Fprint(channel, "\r\nWelcome\r\n")
+				_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(exitStatus{Status: 0}))
+			} else {
+				_, _ = fmt.Fprint(channel.Stderr(), "\r\nDenied\r\n")
+				_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(exitStatus{Status: 1}))
+			}
+			return
+		}
+	}
+}
+
+func TestSession(t *testing.T) {
+	m, err := mimic.NewMimic(mimic.WithSize(24, 80), mimic.WithIdleTimeout(time.Second))
+	require.NoError(t, err)
+	defer m.Close()
+
+	session := NewSession(m)
+	events := make(chan string, 4)
+	done := make(chan struct{})
+	session.RequestPty("xterm")
+	go func() {
+		defer close(done)
+		handleSession(session, session.Requests(), events)
+	}()
+	assert.Equal(t, "pty xterm 24x80", <-events)
+	assert.NoError(t, m.Resize(30, 100))
+	assert.Equal(t, "resize 30x100", <-events)
+
+	session.RequestShell()
+	assert.NoError(t, m.ExpectString("Password:"))
+	_, err = m.WriteString("hunter2\r")
+	assert.NoError(t, err)
+	assert.NoError(t, m.ExpectString("Welcome"))
+	<-done
+
+	status, ok := session.ExitStatus()
+	assert.True(t, ok)
+	assert.Equal(t, 0, status)
+	assert.Equal(t, "exit-status", session.SentRequests()[0].Type)
+
+	_, err = session.SendRequest("exit-signal", false, nil)
+	assert.Error(t, err, "requests can't be sent once the session is closed")
+	_, open := <-session.Requests()
+	assert.False(t, open)
+}
@@ -0,0 +1,142 @@
+/*
+Package mimictest provides fluent, test-bound helpers for driving a mimic.Mimic.
+
+Each step reports its own failure through the bound testing.TB, so a test reads as a sequence of interactions without
+wrapping every call in an error check:
+
+	mimictest.Assert(t, m).
+		WriteString("make build\r").
+		ExpectString("Build complete").
+		ContainsString("0 errors")
+
+A failing step fails the test via Fatalf, with a message describing the step, the time elapsed since Assert was
+called, and the formatted terminal view. Steps must therefore be invoked from the goroutine running the test.
+Once a step has failed, later steps in the chain are skipped.
+*/
+package mimictest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jimschubert/mimic"
+)
+
+// Assertion binds a Mimic to a test. See Assert.
+type Assertion struct {
+	t       testing.TB
+	m       *mimic.Mimic
+	started time.Time
+	failed  bool
+}
+
+// Assert binds m to t, returning an Assertion whose steps fail t rather than returning errors
+func Assert(t testing.TB, m *mimic.Mimic) *Assertion {
+	t.Helper()
+	return &Assertion{t: t, m: m, started: time.Now()}
+}
+
+// WriteString writes str to the emulated terminal. See mimic.Mimic.WriteString.
+func (a *Assertion) WriteString(str string) *Assertion {
+	a.t.Helper()
+	return a.check("WriteString", []string{str}, func() error {
+		_, err := a.m.WriteString(str)
+		return err
+	})
+}
+
+// SendKey writes keys to the emulated terminal. See mimic.Mimic.SendKey.
+func (a *Assertion) SendKey(keys ...mimic.Key) *Assertion {
+	a.t.Helper()
+	args := make([]string, 0, len(keys))
+	for _, k := range keys {
+		args = append(args, string(k))
+	}
+	return a.check("SendKey", args, func() error {
+		return a.m.SendKey(keys...)
+	})
+}
+
+// ExpectString waits for the emulated terminal's view to contain one or more strings. See mimic.Mimic.ExpectString.
+func (a *Assertion) ExpectString(str ...string) *Assertion {
+	a.t.Helper()
+	return a.check("ExpectString", str, func() error {
+		return a.m.ExpectString(str...)
+	})
+}
+
+// ExpectPattern waits for the emulated terminal's view to match one or more patterns. See mimic.Mimic.ExpectPattern.
+func (a *Assertion) ExpectPattern(pattern ...string) *Assertion {
+	a.t.Helper()
+	return a.check("ExpectPattern", pattern, func() error {
+		_, err := a.m.ExpectPattern(pattern...)
+		return err
+	})
+}
+
+// ContainsString checks that the emulated terminal's view currently contains all strings.
+// See mimic.Mimic.ContainsString.
+func (a *Assertion) ContainsString(str ...string) *Assertion {
+	a.t.Helper()
+	return a.check("ContainsString", str, func() error {
+		if !a.m.ContainsString(str...) {
+			return fmt.Errorf("view does not contain all strings")
+		}
+		return nil
+	})
+}
+
+// ContainsPattern checks that the emulated terminal's view currently matches all patterns.
+// See mimic.Mimic.ContainsPattern.
+func (a *Assertion) ContainsPattern(pattern ...string) *Assertion {
+	a.t.Helper()
+	return a.check("ContainsPattern", pattern, func() error {
+		for _, p := range pattern {
+			if _, err := mimic.CompilePattern(p); err != nil {
+				return err
+			}
+		}
+		if !a.m.ContainsPattern(pattern...) {
+			return fmt.Errorf("view does not match all patterns")
+		}
+		return nil
+	})
+}
+
+// NoMoreExpectations waits for the underlying console to reach EOF. See mimic.Mimic.NoMoreExpectations.
+func (a *Assertion) NoMoreExpectations() *Assertion {
+	a.t.Helper()
+	return a.check("NoMoreExpectations", nil, a.m.NoMoreExpectations)
+}
+
+// check invokes step unless an earlier step failed, failing the test with context if step returns an error
+func (a *Assertion) check(operation string, args []string, step func() error) *Assertion {
+	a.t.Helper()
+	if a.failed {
+		return a
+	}
+
+	began := time.Now()
+	err := step()
+	if err == nil {
+		return a
+	}
+
+	a.failed = true
+	_ = a.m.Flush()
+	a.t.Fatalf("%s failed after %s (%s since Assert): %v\n\n%s",
+		mimic.FormatCall(operation, args),
+		time.Since(began).Round(time.Millisecond),
+		time.Since(a.started).Round(time.Millisecond),
+		err,
+		render(a.m),
+	)
+	return a
+}
+
+// render formats the view of m as a numbered screen (see mimic.FormatScreen)
+func render(m *mimic.Mimic) string {
+	v := mimic.Viewer{Mimic: m, StripAnsi: true, Trim: true}
+	return "Screen:\n" + mimic.FormatScreen(v.Lines(), 0)
+}
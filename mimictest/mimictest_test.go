@@ -0,0 +1,72 @@
+package mimictest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jimschubert/mimic"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTB captures fatal failures rather than stopping the test
+type recordingTB struct {
+	testing.TB
+	fatals []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Fatalf(format string, args ...interface{}) {
+	r.fatals = append(r.fatals, fmt.Sprintf(format, args...))
+}
+
+func newMimic(t *testing.T) *mimic.Mimic {
+	m, err := mimic.NewMimic(mimic.WithSize(5, 20), mimic.WithIdleTimeout(50*time.Millisecond))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = m.Close() })
+	return m
+}
+
+func TestAssert(t *testing.T) {
+	m := newMimic(t)
+	_, err := m.Tty().WriteString("Hello\r\nWorld 42")
+	require.NoError(t, err)
+
+	rt := &recordingTB{TB: t}
+	Assert(rt, m).
+		ExpectString("Hello").
+		ExpectPattern(`World \d+`).
+		ContainsString("Hello", "World").
+		ContainsPattern(`\d{2}`)
+	require.Empty(t, rt.fatals)
+}
+
+func TestAssert_failureReportsContext(t *testing.T) {
+	m := newMimic(t)
+	_, err := m.Tty().WriteString("Hello")
+	require.NoError(t, err)
+
+	rt := &recordingTB{TB: t}
+	Assert(rt, m).
+		ExpectString("Goodbye").
+		ContainsString("Hello")
+
+	require.Len(t, rt.fatals, 1, "steps after a failure should be skipped")
+	require.Contains(t, rt.fatals[0], `ExpectString("Goodbye") failed after`)
+	require.Contains(t, rt.fatals[0], "since Assert")
+	require.Contains(t, rt.fatals[0], "  0 | Hello")
+}
+
+func TestAssert_containsFailure(t *testing.T) {
+	m := newMimic(t)
+	_, err := m.Tty().WriteString("Hello")
+	require.NoError(t, err)
+
+	rt := &recordingTB{TB: t}
+	Assert(rt, m).ContainsString("Hello", "Puppies")
+
+	require.Len(t, rt.fatals, 1)
+	require.Contains(t, rt.fatals[0], `ContainsString("Hello", "Puppies") failed after`)
+	require.Contains(t, rt.fatals[0], "view does not contain all strings")
+}
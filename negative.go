@@ -0,0 +1,82 @@
+package mimic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jimschubert/mimic/internal"
+	"github.com/jimschubert/mimic/internal/expect"
+	"github.com/jimschubert/stripansi"
+)
+
+// ExpectNotString watches output for the duration of window, returning an UnexpectedOutputError if any of the
+// specified strings appear. This evaluates the output stream as it arrives (like Mimic.ExpectString), so it can't race
+// with a writer the way a view-based absence check can. ExpectNotString always blocks for window unless output matches
+// or the terminal is closed.
+//
+// Output read during the window is consumed, as it is by an expectation: a later Mimic.ExpectString won't match
+// output which arrived during the window, though it remains in the view for checks such as Mimic.ContainsString.
+func (m *Mimic) ExpectNotString(window time.Duration, str ...string) error {
+	matchers := make([]expect.Matcher, 0, len(str))
+	for _, s := range str {
+		matchers = append(matchers, m.stringMatcher(s))
+	}
+	return m.expectNot("ExpectNotString", window, str, matchers)
+}
+
+// ExpectNotPattern watches output for the duration of window, returning an UnexpectedOutputError if any of the
+// specified patterns match. See Mimic.ExpectNotString. An error wrapping ErrInvalidPattern is returned, without
+// watching, if any pattern is invalid.
+//
+// Output read during the window is consumed, as it is by an expectation: a later Mimic.ExpectPattern won't match
+// output which arrived during the window, though it remains in the view for checks such as Mimic.ContainsPattern.
+func (m *Mimic) ExpectNotPattern(window time.Duration, pattern ...string) error {
+	regexes, err := compilePatterns(pattern)
+	if err != nil {
+		return err
+	}
+	matchers := regexpMatchers(regexes)
+	return m.expectNot("ExpectNotPattern", window, pattern, matchers)
+}
+
+// ExpectSilence succeeds only if nothing is written to the pty for the duration d, which is useful for asserting that an
+// application blocks on input. Output written before the call but not yet read (see Mimic.Flush) is considered output.
+func (m *Mimic) ExpectSilence(d time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	output, matched, err := m.With(WithCallTimeout(d)).watch(ctx, &internal.OutputMatcher{})
+	err = ignoreQuietErr(err)
+	if matched != nil {
+		err = fmt.Errorf("expected silence for %s, but received output: %q", d, output)
+	}
+	m.transcript.record("ExpectSilence", []string{d.String()}, stripansi.String(output), err)
+	return err
+}
+
+func (m *Mimic) expectNot(operation string, window time.Duration, criteria []string, matchers []expect.Matcher) error {
+	ctx, cancel := context.WithTimeout(context.Background(), window)
+	defer cancel()
+
+	output, matched, err := m.With(WithCallTimeout(window)).watch(ctx, matchers...)
+	output = stripansi.String(output)
+	err = ignoreQuietErr(err)
+	for i, matcher := range matchers {
+		if matched != nil && matcher == matched {
+			err = UnexpectedOutputError{Criteria: criteria[i], Contents: output}
+			break
+		}
+	}
+	m.transcript.record(operation, criteria, output, err)
+	return err
+}
+
+// ignoreQuietErr discards errors which indicate an expectation ended without output matching
+func ignoreQuietErr(err error) error {
+	if err == nil || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrExpectTimeout) || errors.Is(err, ErrEOF) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,142 @@
+package mimic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_ExpectNotString(t *testing.T) {
+	tests := []struct {
+		name    string
+		writes  []string
+		str     []string
+		wantErr string
+	}{
+		{name: "absent output succeeds", writes: []string{"Logging in…", "Done."}, str: []string{"hunter2"}},
+		{name: "present output fails", writes: []string{"Logging in…", "password=hunter2", "Done."}, str: []string{"warning", "hunter2"}, wantErr: "hunter2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMimic(WithIdleTimeout(10 * time.Millisecond))
+			assert.NoError(t, err)
+			defer m.Close()
+
+			go func() {
+				for _, s := range tt.writes {
+					time.Sleep(30 * time.Millisecond)
+					_, _ = m.Tty().WriteString(s)
+				}
+			}()
+
+			window := 200 * time.Millisecond
+			started := time.Now()
+			err = m.ExpectNotString(window, tt.str...)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.GreaterOrEqual(t, time.Since(started), window, "absence must be observed for the whole window")
+				return
+			}
+
+			var unexpected UnexpectedOutputError
+			assert.ErrorAs(t, err, &unexpected)
+			assert.Equal(t, tt.wantErr, unexpected.Criteria)
+			assert.Contains(t, unexpected.Contents, "password=hunter2")
+		})
+	}
+}
+
+func TestMimic_ExpectNotPattern(t *testing.T) {
+	m, err := NewMimic()
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Tty().WriteString("\x1b[33mWARN\x1b[0m deprecated flag")
+	assert.NoError(t, err)
+
+	assert.Error(t, m.ExpectNotPattern(100*time.Millisecond, `(?i)warn\s+deprecated`))
+	assert.NoError(t, m.ExpectNotPattern(50*time.Millisecond, `ERROR`))
+	assert.ErrorIs(t, m.ExpectNotPattern(50*time.Millisecond, `[unclosed`), ErrInvalidPattern)
+}
+
+func TestMimic_ExpectNotString_consumes(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(10 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Tty().WriteString("Done.")
+	assert.NoError(t, err)
+
+	assert.NoError(t, m.ExpectNotString(50*time.Millisecond, "hunter2"))
+	assert.True(t, m.ContainsString("Done."), "output read during the window remains in the view")
+	assert.ErrorIs(t, m.ExpectString("Done."), ErrExpectTimeout, "output read during the window is consumed")
+}
+
+func TestMimic_ExpectSilence(t *testing.T) {
+	m, err := NewMimic()
+	assert.NoError(t, err)
+	defer m.Close()
+
+	started := time.Now()
+	assert.NoError(t, m.ExpectSilence(100*time.Millisecond))
+	assert.GreaterOrEqual(t, time.Since(started), 100*time.Millisecond)
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		_, _ = m.Tty().WriteString("still going")
+	}()
+	err = m.ExpectSilence(200 * time.Millisecond)
+	assert.ErrorContains(t, err, "received output")
+}
+
+func TestMimic_ExpectNotString_notReported(t *testing.T) {
+	m, err := NewMimic(WithStrict(), WithTranscript(), WithIdleTimeout(20*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	var matches, timeouts int
+	m.OnMatch(func(Match) { matches++ })
+	m.OnTimeout(func(Expectation) { timeouts++ })
+
+	assert.NoError(t, m.ExpectNotString(50*time.Millisecond, "hunter2"))
+	_, err = m.Tty().WriteString("password=hunter2\r\n")
+	assert.NoError(t, err)
+	assert.Error(t, m.ExpectNotString(100*time.Millisecond, "hunter2"))
+
+	stats := m.Stats()
+	assert.Zero(t, stats.Expectations)
+	assert.Zero(t, stats.TimedOut)
+	assert.Zero(t, matches)
+	assert.Zero(t, timeouts)
+
+	transcript := m.Transcript()
+	if assert.Len(t, transcript, 2) {
+		assert.Equal(t, "ExpectNotString", transcript[0].Operation)
+		assert.NoError(t, transcript[0].Err)
+		assert.Error(t, transcript[1].Err)
+	}
+	assert.ErrorContains(t, m.NoMoreExpectations(), "password=hunter2", "forbidden output must remain unmatched")
+}
+
+func TestMimic_ExpectSilence_notReported(t *testing.T) {
+	m, err := NewMimic(WithStrict(), WithIdleTimeout(20*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	var matches, timeouts int
+	m.OnMatch(func(Match) { matches++ })
+	m.OnTimeout(func(Expectation) { timeouts++ })
+
+	assert.NoError(t, m.ExpectSilence(50*time.Millisecond))
+	_, err = m.Tty().WriteString("still going\r\n")
+	assert.NoError(t, err)
+	assert.Error(t, m.ExpectSilence(100*time.Millisecond))
+
+	stats := m.Stats()
+	assert.Zero(t, stats.Expectations)
+	assert.Zero(t, stats.TimedOut)
+	assert.Zero(t, matches)
+	assert.Zero(t, timeouts)
+	assert.ErrorContains(t, m.NoMoreExpectations(), "still going")
+}
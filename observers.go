@@ -0,0 +1,105 @@
+package mimic
+
+import (
+	"sync"
+	"time"
+)
+
+// Match describes an expectation which matched, as reported to handlers registered via Mimic.OnMatch
+type Match struct {
+	// Criteria is the string or pattern which matched
+	Criteria string
+	// Output read from the terminal during the expectation, with ANSI sequences removed
+	Output string
+	// Duration of the expectation
+	Duration time.Duration
+}
+
+// Expectation describes an expectation which ended without matching, as reported to handlers registered via
+// Mimic.OnTimeout
+type Expectation struct {
+	// Criteria lists the strings or patterns which were expected
+	Criteria []string
+	// Output read from the terminal during the expectation, with ANSI sequences removed
+	Output string
+	// Duration of the expectation
+	Duration time.Duration
+	// Err which ended the expectation
+	Err error
+}
+
+// observers holds handlers registered via OnWrite, OnMatch, and OnTimeout
+type observers struct {
+	mu       sync.RWMutex
+	writes   []func([]byte)
+	matches  []func(Match)
+	timeouts []func(Expectation)
+}
+
+func (o *observers) write(data []byte) {
+	o.mu.RLock()
+	handlers := make([]func([]byte), len(o.writes))
+	copy(handlers, o.writes)
+	o.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(data)
+	}
+}
+
+func (o *observers) match(match Match) {
+	o.mu.RLock()
+	handlers := make([]func(Match), len(o.matches))
+	copy(handlers, o.matches)
+	o.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(match)
+	}
+}
+
+func (o *observers) timeout(expectation Expectation) {
+	o.mu.RLock()
+	handlers := make([]func(Expectation), len(o.timeouts))
+	copy(handlers, o.timeouts)
+	o.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(expectation)
+	}
+}
+
+// OnWrite registers a handler which is invoked with the input written to the emulated terminal, e.g. via
+// Mimic.WriteString or Mimic.SendKey. Handlers are invoked synchronously, in the order they were registered, and must
+// not retain data.
+func (m *Mimic) OnWrite(handler func(data []byte)) {
+	if handler == nil {
+		return
+	}
+	m.observers.mu.Lock()
+	defer m.observers.mu.Unlock()
+	m.observers.writes = append(m.observers.writes, handler)
+}
+
+// OnMatch registers a handler which is invoked whenever an expectation (e.g. Mimic.ExpectString) matches.
+// Handlers are invoked synchronously, in the order they were registered.
+func (m *Mimic) OnMatch(handler func(Match)) {
+	if handler == nil {
+		return
+	}
+	m.observers.mu.Lock()
+	defer m.observers.mu.Unlock()
+	m.observers.matches = append(m.observers.matches, handler)
+}
+
+// OnTimeout registers a handler which is invoked whenever an expectation times out waiting for output, i.e. fails with
+// ErrExpectTimeout. Expectations abandoned because their context is done or the Mimic is closed are not reported.
+// Handlers are invoked synchronously, in the order they were registered.
+func (m *Mimic) OnTimeout(handler func(Expectation)) {
+	if handler == nil {
+		return
+	}
+	m.observers.mu.Lock()
+	defer m.observers.mu.Unlock()
+	m.observers.timeouts = append(m.observers.timeouts, handler)
+}
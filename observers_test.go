@@ -0,0 +1,42 @@
+package mimic
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_observers(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(50 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	var writes []string
+	var matches []Match
+	var timeouts []Expectation
+	m.OnWrite(func(data []byte) { writes = append(writes, string(data)) })
+	m.OnMatch(func(match Match) { matches = append(matches, match) })
+	m.OnTimeout(func(expectation Expectation) { timeouts = append(timeouts, expectation) })
+	m.OnWrite(nil)
+
+	_, err = m.WriteString("Hello")
+	assert.NoError(t, err)
+	assert.NoError(t, m.SendKey(KeyEnter))
+	assert.NoError(t, m.ExpectString("Hello"))
+	assert.Error(t, m.ExpectString("Goodbye"))
+
+	assert.Equal(t, []string{"Hello", string(KeyEnter)}, writes)
+
+	if assert.Len(t, matches, 1) {
+		assert.Equal(t, "Hello", matches[0].Criteria)
+		assert.Contains(t, matches[0].Output, "Hello")
+		assert.Greater(t, matches[0].Duration, time.Duration(0))
+	}
+	if assert.Len(t, timeouts, 1) {
+		assert.Equal(t, []string{"Goodbye"}, timeouts[0].Criteria)
+		assert.True(t, errors.Is(timeouts[0].Err, ErrExpectTimeout))
+		assert.GreaterOrEqual(t, timeouts[0].Duration, 50*time.Millisecond)
+	}
+}
@@ -0,0 +1,144 @@
+package mimic
+
+import "bytes"
+
+// maxControlPayload bounds the payload retained for a single control sequence; longer sequences are discarded
+const maxControlPayload = 1 << 20
+
+type controlState int
+
+const (
+	controlGround controlState = iota
+	controlEscape
+	// controlCSI is a control sequence (e.g. ESC [ 1 m), whose parameters aren't text
+	controlCSI
+	// controlString is a control string: OSC, DCS, SOS, PM, or APC
+	controlString
+	controlStringEscape
+)
+
+// control is a complete control sequence recognized by controlParser
+type control struct {
+	// kind identifies the sequence by its introducer: '[' for CSI, ']' for OSC, 'P' for DCS, 'X' for SOS, '^' for PM,
+	// '_' for APC, or '\a' for a bell
+	kind byte
+	// payload is the content of the sequence between its introducer and terminator, e.g. "8;;uri" for
+	// ESC ] 8 ; ; uri ST. The final byte of a CSI sequence is included, e.g. "6n" for ESC [ 6 n. The payload is only
+	// valid until the parser is next fed.
+	payload []byte
+}
+
+// is reports whether c is a sequence of the given kind whose payload begins with prefix
+func (c control) is(kind byte, prefix []byte) bool {
+	return c.kind == kind && bytes.HasPrefix(c.payload, prefix)
+}
+
+// sequence returns c as an escape sequence, e.g. "\x1b[6n". Control strings are terminated with ST (ESC \), even if
+// they were terminated with BEL.
+func (c control) sequence() string {
+	switch c.kind {
+	case '\a':
+		return "\a"
+	case '[':
+		return "\x1b[" + string(c.payload)
+	default:
+		return "\x1b" + string(c.kind) + string(c.payload) + "\x1b\\"
+	}
+}
+
+// controlParser recognizes control sequences in output which the view doesn't retain, such as OSC (operating system
+// command) sequences, and BEL characters which ring the bell rather than terminate a control string. It's fed a byte at
+// a time, as sequences may be split across writes.
+type controlParser struct {
+	state    controlState
+	kind     byte
+	payload  []byte
+	overflow bool
+}
+
+// feed advances the parser by b, returning the control sequence which b completes, if any
+func (p *controlParser) feed(b byte) (control, bool) {
+	switch p.state {
+	case controlGround:
+		switch b {
+		case '\x1b':
+			p.state = controlEscape
+		case '\a':
+			return control{kind: '\a'}, true
+		}
+	case controlEscape:
+		switch b {
+		case '[':
+			p.begin(controlCSI, b)
+		case ']', 'P', 'X', '^', '_':
+			p.begin(controlString, b)
+		case '\x1b':
+		default:
+			p.state = controlGround
+		}
+	case controlCSI:
+		switch {
+		case b == '\a':
+			// C0 controls are executed within a sequence
+			return control{kind: '\a'}, true
+		case b == '\x1b':
+			p.state = controlEscape
+		case b == '\x18', b == '\x1a':
+			// CAN and SUB abort the sequence
+			p.state = controlGround
+		case b >= 0x40 && b <= 0x7e:
+			p.append(b)
+			return p.complete()
+		default:
+			p.append(b)
+		}
+	case controlString:
+		switch b {
+		case '\a':
+			return p.complete()
+		case '\x1b':
+			p.state = controlStringEscape
+		case '\x18', '\x1a':
+			p.state = controlGround
+		default:
+			p.append(b)
+		}
+	case controlStringEscape:
+		if b == '\\' {
+			return p.complete()
+		}
+		// an unterminated string, followed by another escape sequence
+		p.state = controlEscape
+		return p.feed(b)
+	}
+	return control{}, false
+}
+
+// text reports whether b, which the parser was in state before being fed, is printable text rather than part of a
+// control sequence
+func (p *controlParser) text(before controlState, b byte) bool {
+	return before == controlGround && p.state == controlGround && b >= ' ' && b != 0x7f
+}
+
+func (p *controlParser) begin(state controlState, kind byte) {
+	p.state = state
+	p.kind = kind
+	p.payload = p.payload[:0]
+	p.overflow = false
+}
+
+func (p *controlParser) append(b byte) {
+	if len(p.payload) < maxControlPayload {
+		p.payload = append(p.payload, b)
+	} else {
+		p.overflow = true
+	}
+}
+
+func (p *controlParser) complete() (control, bool) {
+	p.state = controlGround
+	if p.overflow {
+		return control{}, false
+	}
+	return control{kind: p.kind, payload: p.payload}, true
+}
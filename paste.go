@@ -0,0 +1,64 @@
+package mimic
+
+import (
+	"bytes"
+	"sync"
+)
+
+const (
+	bracketedPasteEnable  = "\x1b[?2004h"
+	bracketedPasteDisable = "\x1b[?2004l"
+	bracketedPasteStart   = "\x1b[200~"
+	bracketedPasteEnd     = "\x1b[201~"
+)
+
+// Paste sends s as if it were pasted into the terminal. If the application has enabled bracketed paste mode
+// (DECSET 2004), s is wrapped in the bracketed paste start and end sequences so the application can distinguish it
+// from typed input; otherwise s is sent as-is. Pending output is flushed first, so a mode change written before
+// Paste is observed.
+func (m *Mimic) Paste(s string) error {
+	if err := m.Flush(); err != nil {
+		return err
+	}
+
+	if m.modes.bracketedPasteEnabled() {
+		s = bracketedPasteStart + s + bracketedPasteEnd
+	}
+	_, err := m.WriteString(s)
+	return err
+}
+
+// modeTracker observes application output for terminal modes which the emulated terminal doesn't track.
+type modeTracker struct {
+	mu             sync.Mutex
+	tail           []byte
+	bracketedPaste bool
+}
+
+func (t *modeTracker) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// a sequence may be split across writes, so evaluate the end of the previous write along with this one
+	data := append(t.tail, p...)
+	enable := bytes.LastIndex(data, []byte(bracketedPasteEnable))
+	disable := bytes.LastIndex(data, []byte(bracketedPasteDisable))
+	if enable > disable {
+		t.bracketedPaste = true
+	} else if disable > enable {
+		t.bracketedPaste = false
+	}
+
+	keep := len(bracketedPasteEnable) - 1
+	if len(data) < keep {
+		keep = len(data)
+	}
+	t.tail = append(t.tail[:0], data[len(data)-keep:]...)
+	return len(p), nil
+}
+
+func (t *modeTracker) bracketedPasteEnabled() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.bracketedPaste
+}
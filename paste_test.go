@@ -0,0 +1,41 @@
+package mimic
+
+import (
+	"bufio"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_Paste(t *testing.T) {
+	tests := []struct {
+		name  string
+		modes []string
+		want  string
+	}{
+		{name: "paste mode disabled", want: "hello\n"},
+		{name: "paste mode enabled", modes: []string{"\x1b[?20", "04h"}, want: "\x1b[200~hello\x1b[201~\n"},
+		{name: "paste mode toggled off", modes: []string{"\x1b[?2004h", "prompt> ", "\x1b[?2004l"}, want: "hello\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMimic(WithIdleTimeout(time.Second))
+			assert.NoError(t, err)
+			defer m.Close()
+
+			for _, mode := range tt.modes {
+				_, err = m.Tty().WriteString(mode)
+				assert.NoError(t, err)
+				assert.NoError(t, m.Flush())
+			}
+
+			assert.NoError(t, m.Paste("hello"))
+			assert.NoError(t, m.SendKey(KeyEnter))
+
+			line, err := bufio.NewReader(m.Tty()).ReadString('\n')
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, line)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package mimic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// Process is a child process whose standard streams are attached to a Mimic's pseudo terminal.
+// See Mimic.Spawn and Mimic.AttachCommand.
+type Process struct {
+	cmd     *exec.Cmd
+	done    chan struct{}
+	err     error
+	timeout time.Duration
+}
+
+// ExitStatus describes how a process exited. See Process.WaitContext.
+type ExitStatus struct {
+	// Code is the exit code of the process, or -1 if it was terminated by a signal
+	Code int
+	// Signal which terminated the process, or nil if it exited normally
+	Signal os.Signal
+}
+
+// Success reports whether the process exited with code 0
+func (s ExitStatus) Success() bool {
+	return s.Code == 0
+}
+
+// String describes the exit status, e.g. "exit code 3" or "signal: interrupt"
+func (s ExitStatus) String() string {
+	if s.Signal != nil {
+		return fmt.Sprintf("signal: %v", s.Signal)
+	}
+	return fmt.Sprintf("exit code %d", s.Code)
+}
+
+// WithEnv sets environment variables, each in the form "key=value", for processes started via Mimic.Spawn and
+// Mimic.AttachCommand. This allows rendering to be configured as the emulated terminal expects, e.g. via NO_COLOR or
+// COLUMNS and LINES. Later values for a key take precedence, and the environment of a command passed to
+// Mimic.AttachCommand takes precedence over all of them.
+func WithEnv(env ...string) Option {
+	return func(opt *mimicOpt) {
+		opt.env = append(opt.env, env...)
+	}
+}
+
+// WithTerm sets the TERM environment variable for processes started via Mimic.Spawn and Mimic.AttachCommand, which
+// determines the capabilities applications assume of the terminal (e.g. "xterm-256color" or "dumb"). See WithEnv.
+func WithTerm(term string) Option {
+	return WithEnv("TERM=" + term)
+}
+
+// Spawn starts the named program with the given arguments under the emulated terminal. The process is killed
+// if ctx is done before the process exits. See Mimic.AttachCommand.
+func (m *Mimic) Spawn(ctx context.Context, name string, args ...string) (*Process, error) {
+	return m.AttachCommand(exec.CommandContext(ctx, name, args...))
+}
+
+// Attach configures cmd to run under the emulated terminal of m, without starting it: the standard input, output, and
+// error of cmd are wired to the terminal, and cmd is configured to start in a new session with the terminal as its
+// controlling terminal. The pty's window size is synchronized with the emulated terminal, and any environment variables
+// configured via WithEnv or WithTerm are added to cmd's environment. cmd must not have been started. If the terminal
+// is emulated in memory (see WithInMemoryPty), Attach returns ErrNoPty.
+//
+// Attach suits commands which are started and waited on by other code, e.g. via cmd.Run. See Mimic.AttachCommand to
+// also start cmd.
+func Attach(cmd *exec.Cmd, m *Mimic) error {
+	tty, err := m.TtyFile()
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = tty
+	cmd.Stdout = tty
+	cmd.Stderr = tty
+	cmd.SysProcAttr = withControllingTerminal(cmd.SysProcAttr)
+	cmd.Env = m.processEnv(cmd.Env)
+
+	rows, columns := m.Size()
+	return setPtySize(tty, rows, columns)
+}
+
+// AttachCommand configures cmd to run under the emulated terminal (see Attach), starts it, and returns a handle for
+// waiting on its exit. cmd must not have been started.
+func (m *Mimic) AttachCommand(cmd *exec.Cmd) (*Process, error) {
+	if err := Attach(cmd, m); err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	p := &Process{cmd: cmd, done: make(chan struct{}), timeout: m.maxIdleWait}
+	go func() {
+		defer close(p.done)
+		p.err = cmd.Wait()
+	}()
+
+	return p, nil
+}
+
+// processEnv is the environment for a command whose configured environment is env: the environment configured via
+// WithEnv, overridden by env. As with exec.Cmd, a nil env inherits the current process's environment.
+func (m *Mimic) processEnv(env []string) []string {
+	if len(m.env) == 0 {
+		return env
+	}
+	if env == nil {
+		return append(os.Environ(), m.env...)
+	}
+	combined := make([]string, 0, len(m.env)+len(env))
+	combined = append(combined, m.env...)
+	return append(combined, env...)
+}
+
+// Pid is the process id of the child process
+func (p *Process) Pid() int {
+	return p.cmd.Process.Pid
+}
+
+// Done is closed once the process has exited
+func (p *Process) Done() <-chan struct{} {
+	return p.done
+}
+
+// Wait blocks until the process exits, returning the same error as exec.Cmd.Wait.
+// Unlike exec.Cmd.Wait, Wait may be invoked multiple times and from multiple goroutines.
+func (p *Process) Wait() error {
+	<-p.done
+	return p.err
+}
+
+// WaitContext blocks until the process exits or ctx is done, returning how the process exited. Unlike Wait, exiting
+// with a non-zero code or by a signal isn't an error; errors describe a failure to wait for the process (e.g. copying
+// its output), or ctx's error if it's done first.
+func (p *Process) WaitContext(ctx context.Context) (ExitStatus, error) {
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		return ExitStatus{Code: -1}, ctx.Err()
+	}
+
+	status := ExitStatus{Code: p.cmd.ProcessState.ExitCode(), Signal: exitSignal(p.cmd.ProcessState)}
+	var exitErr *exec.ExitError
+	if p.err != nil && !errors.As(p.err, &exitErr) {
+		return status, p.err
+	}
+	return status, nil
+}
+
+// ExpectExit waits for the process to exit with code, returning an error if it exits otherwise. Waiting is bounded by
+// the idle timeout of the Mimic which started the process (see WithIdleTimeout); if the process hasn't exited by then,
+// the error wraps ErrExpectTimeout.
+func (p *Process) ExpectExit(code int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	status, err := p.WaitContext(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w: process did not exit within %s, expected exit code %d", ErrExpectTimeout, p.timeout, code)
+	}
+	if err != nil {
+		return err
+	}
+	if status.Signal != nil || status.Code != code {
+		return fmt.Errorf("process exited with %s, expected exit code %d", status, code)
+	}
+	return nil
+}
+
+// Signal delivers sig to the process group of the process, which includes any children it started (e.g. those of a
+// shell). It returns an error if the process has already exited.
+func (p *Process) Signal(sig os.Signal) error {
+	select {
+	case <-p.done:
+		return os.ErrProcessDone
+	default:
+	}
+	return signalGroup(p.cmd.Process, sig)
+}
+
+// Interrupt delivers os.Interrupt (i.e. SIGINT) to the process group of the process, as pressing Ctrl+C in a terminal
+// would. See Process.Signal.
+func (p *Process) Interrupt() error {
+	return p.Signal(os.Interrupt)
+}
+
+// ExitCode returns the exit code of the exited process, or -1 if the process hasn't exited or was terminated by a signal.
+func (p *Process) ExitCode() int {
+	select {
+	case <-p.done:
+		return p.cmd.ProcessState.ExitCode()
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,23 @@
+//go:build js
+
+package mimic
+
+import (
+	"os"
+	"syscall"
+)
+
+// withControllingTerminal is a no-op, as processes can't be started under js/wasm
+func withControllingTerminal(attr *syscall.SysProcAttr) *syscall.SysProcAttr {
+	return attr
+}
+
+// exitSignal is always nil, as processes can't be started under js/wasm
+func exitSignal(state *os.ProcessState) os.Signal {
+	return nil
+}
+
+// signalGroup delivers sig to process alone, as processes can't be started under js/wasm
+func signalGroup(process *os.Process, sig os.Signal) error {
+	return process.Signal(sig)
+}
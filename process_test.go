@@ -0,0 +1,156 @@
+package mimic
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_Spawn(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(2 * time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	p, err := m.Spawn(context.Background(), "sh", "-c", `printf "name? "; read name; echo "hello $name"; [ -t 0 ] && exit 3`)
+	assert.NoError(t, err)
+	assert.Equal(t, -1, p.ExitCode(), "exit code should be unavailable while running")
+
+	assert.NoError(t, m.ExpectString("name?"))
+	_, err = m.WriteString("Tom\r")
+	assert.NoError(t, err)
+	assert.NoError(t, m.ExpectString("hello Tom"))
+
+	var exitErr *exec.ExitError
+	assert.ErrorAs(t, p.Wait(), &exitErr)
+	assert.Equal(t, 3, p.ExitCode(), "process should see the pty as a terminal")
+	assert.ErrorAs(t, p.Wait(), &exitErr, "Wait should be repeatable")
+}
+
+func TestMimic_Spawn_cancelled(t *testing.T) {
+	m, err := NewMimic()
+	assert.NoError(t, err)
+	defer m.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p, err := m.Spawn(ctx, "sleep", "10")
+	assert.NoError(t, err)
+	cancel()
+
+	select {
+	case <-p.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("process should be killed when its context is cancelled")
+	}
+	assert.Error(t, p.Wait())
+}
+
+func TestMimic_Spawn_env(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(2*time.Second), WithTerm("dumb"), WithEnv("NO_COLOR=1", "LINES=10", "LINES=24"))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	p, err := m.Spawn(context.Background(), "sh", "-c", `echo "term=$TERM no_color=$NO_COLOR lines=$LINES home=${HOME:+set}"`)
+	assert.NoError(t, err)
+	assert.NoError(t, m.ExpectString("term=dumb no_color=1 lines=24 home=set"))
+	assert.NoError(t, p.Wait())
+}
+
+func TestMimic_AttachCommand_envPrecedence(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(2*time.Second), WithTerm("dumb"), WithEnv("NO_COLOR=1"))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	cmd := exec.Command("sh", "-c", `echo "term=$TERM no_color=$NO_COLOR home=${HOME:-unset}"`)
+	cmd.Env = []string{"TERM=xterm-256color"}
+	p, err := m.AttachCommand(cmd)
+	assert.NoError(t, err)
+	assert.NoError(t, m.ExpectString("term=xterm-256color no_color=1 home=unset"))
+	assert.NoError(t, p.Wait())
+}
+
+func TestProcess_WaitContext(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(2 * time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	p, err := m.Spawn(context.Background(), "sh", "-c", `read line; exit 3`)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	status, err := p.WaitContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, -1, status.Code)
+
+	_, err = m.WriteString("\r")
+	assert.NoError(t, err)
+	status, err = p.WaitContext(context.Background())
+	assert.NoError(t, err, "exiting with a non-zero code is not an error")
+	assert.Equal(t, ExitStatus{Code: 3}, status)
+	assert.False(t, status.Success())
+	assert.Equal(t, "exit code 3", status.String())
+}
+
+func TestProcess_WaitContext_signal(t *testing.T) {
+	m, err := NewMimic()
+	assert.NoError(t, err)
+	defer m.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p, err := m.Spawn(ctx, "sleep", "10")
+	assert.NoError(t, err)
+	cancel()
+
+	status, err := p.WaitContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, -1, status.Code)
+	assert.Equal(t, "signal: killed", status.String())
+}
+
+func TestProcess_ExpectExit(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(100 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	p, err := m.Spawn(context.Background(), "sh", "-c", `read line; exit 3`)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, p.ExpectExit(3), ErrExpectTimeout, "process is still waiting for input")
+
+	_, err = m.WriteString("\r")
+	assert.NoError(t, err)
+	assert.NoError(t, p.ExpectExit(3))
+	assert.EqualError(t, p.ExpectExit(0), "process exited with exit code 3, expected exit code 0")
+}
+
+func TestProcess_Interrupt(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(2 * time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	p, err := m.Spawn(context.Background(), "sh", "-c", `trap 'echo "cleaning up"; exit 130' INT; echo ready; sleep 10 & wait`)
+	assert.NoError(t, err)
+	assert.NoError(t, m.ExpectString("ready"))
+
+	assert.NoError(t, p.Interrupt())
+	assert.NoError(t, m.ExpectString("cleaning up"))
+	assert.NoError(t, p.ExpectExit(130))
+	assert.ErrorIs(t, p.Signal(os.Interrupt), os.ErrProcessDone)
+}
+
+func TestAttach(t *testing.T) {
+	m, err := NewMimic(WithSize(30, 100), WithIdleTimeout(2*time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	cmd := exec.Command("sh", "-c", `stty size; [ -t 0 ] && exit 3`)
+	assert.NoError(t, Attach(cmd, m))
+
+	var exitErr *exec.ExitError
+	assert.ErrorAs(t, cmd.Run(), &exitErr)
+	assert.Equal(t, 3, cmd.ProcessState.ExitCode(), "process should see the pty as a terminal")
+	assert.NoError(t, m.ExpectString("30 100"))
+}
@@ -0,0 +1,36 @@
+//go:build !windows && !js
+
+package mimic
+
+import (
+	"os"
+	"syscall"
+)
+
+// withControllingTerminal starts the process in a new session, with its standard input as the controlling terminal
+func withControllingTerminal(attr *syscall.SysProcAttr) *syscall.SysProcAttr {
+	if attr == nil {
+		attr = &syscall.SysProcAttr{}
+	}
+	attr.Setsid = true
+	attr.Setctty = true
+	attr.Ctty = 0
+	return attr
+}
+
+// exitSignal is the signal which terminated the process described by state, or nil if it exited normally
+func exitSignal(state *os.ProcessState) os.Signal {
+	if status, ok := state.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return status.Signal()
+	}
+	return nil
+}
+
+// signalGroup delivers sig to the process group led by process, which Mimic.AttachCommand starts in a new session
+func signalGroup(process *os.Process, sig os.Signal) error {
+	s, ok := sig.(syscall.Signal)
+	if !ok {
+		return process.Signal(sig)
+	}
+	return syscall.Kill(-process.Pid, s)
+}
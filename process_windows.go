@@ -0,0 +1,23 @@
+//go:build windows
+
+package mimic
+
+import (
+	"os"
+	"syscall"
+)
+
+// withControllingTerminal is a no-op, as controlling terminals are unsupported on windows
+func withControllingTerminal(attr *syscall.SysProcAttr) *syscall.SysProcAttr {
+	return attr
+}
+
+// exitSignal is always nil, as processes aren't terminated by signals on windows
+func exitSignal(state *os.ProcessState) os.Signal {
+	return nil
+}
+
+// signalGroup delivers sig to process alone, as process groups are unsupported on windows
+func signalGroup(process *os.Process, sig os.Signal) error {
+	return process.Signal(sig)
+}
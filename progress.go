@@ -0,0 +1,116 @@
+package mimic
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	progressPercent  = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*%`)
+	progressFraction = regexp.MustCompile(`(\d+)\s*/\s*(\d+)`)
+)
+
+// Progress returns the progress reported by the most recent row of the emulated terminal's view (or scrollback, see
+// WithScrollback) containing label, after flushing pending output. Progress is read from the first percentage on the
+// row (e.g. "45%" or "12.5 %"), or otherwise from a count such as "3/10", and is returned as a percentage. The result
+// is false if no row contains label, or the row reports no progress.
+//
+// Progress bars typically redraw a single row using a carriage return, so the row reflects only the latest frame.
+func (m *Mimic) Progress(label string) (float64, bool) {
+	_ = m.Flush()
+	row, ok := m.progressRow(label)
+	if !ok {
+		return 0, false
+	}
+	return progressOf(row.text)
+}
+
+// ExpectProgressComplete waits for the progress reported by the row containing label to complete, returning the row's
+// final text. Intermediate frames of a progress bar redrawn with carriage returns never match, even when they contain
+// label, nor do frames only partially written over their predecessor. The row completes once a fully drawn frame
+// reports 100% (or a count such as "10/10"), or once output has moved past the row and it reports no progress at all,
+// e.g. when "Downloading..." is overwritten by "Downloading... done" before a newline.
+//
+// An error is returned if the progress doesn't complete before the idle timeout (see WithIdleTimeout and
+// WithCallTimeout), including when the row is left incomplete, e.g. at "Downloading 45%" because the download failed.
+func (m *Mimic) ExpectProgressComplete(label string) (string, error) {
+	_, _, err := m.expect(context.Background(), &progressMatcher{m: m, label: label})
+	row, _ := m.progressRow(label)
+	if err != nil {
+		return row.text, fmt.Errorf("progress of %q not complete, last seen as %q: %w", label, row.text, err)
+	}
+	return row.text, nil
+}
+
+// progressRow is the most recent row containing a progress label
+type progressRow struct {
+	text string
+	// terminated is whether output has moved past the row, e.g. with a newline
+	terminated bool
+	// drawn is whether the row's latest frame is completely written, i.e. the row is terminated, or the cursor is at its
+	// start (after a carriage return) or end. Mid-frame, a row may combine the new frame with the tail of the previous
+	// one, e.g. "Downloading 175%" while "100%" is written over "75%".
+	drawn bool
+}
+
+// progressRow finds the most recent row containing label
+func (m *Mimic) progressRow(label string) (progressRow, bool) {
+	scrollback := m.scrollback.snapshot()
+	v := Viewer{Mimic: m, StripAnsi: true, Trim: true}
+	lines := append(scrollback, v.Lines()...)
+	row, column := m.cursor()
+	row += len(scrollback)
+
+	for i := len(lines) - 1; i >= 0; i-- {
+		if !strings.Contains(lines[i], label) {
+			continue
+		}
+		terminated := i < row
+		return progressRow{
+			text:       strings.TrimSpace(lines[i]),
+			terminated: terminated,
+			drawn:      terminated || i == row && (column == 0 || column >= textWidth(lines[i])),
+		}, true
+	}
+	return progressRow{}, false
+}
+
+// progressOf reads the progress reported by line as a percentage
+func progressOf(line string) (float64, bool) {
+	if match := progressPercent.FindStringSubmatch(line); match != nil {
+		percent, err := strconv.ParseFloat(match[1], 64)
+		return percent, err == nil
+	}
+	if match := progressFraction.FindStringSubmatch(line); match != nil {
+		done, _ := strconv.Atoi(match[1])
+		total, _ := strconv.Atoi(match[2])
+		if total > 0 {
+			return float64(done) * 100 / float64(total), true
+		}
+	}
+	return 0, false
+}
+
+// progressMatcher matches once the row containing label reports complete progress, regardless of the content read
+type progressMatcher struct {
+	m     *Mimic
+	label string
+}
+
+func (p *progressMatcher) Match(_ interface{}) bool {
+	row, ok := p.m.progressRow(p.label)
+	if !ok {
+		return false
+	}
+	if percent, measured := progressOf(row.text); measured {
+		return row.drawn && percent >= 100
+	}
+	return row.terminated
+}
+
+func (p *progressMatcher) Criteria() interface{} {
+	return p.label
+}
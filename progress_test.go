@@ -0,0 +1,85 @@
+package mimic
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressOf(t *testing.T) {
+	tests := []struct {
+		line   string
+		want   float64
+		wantOk bool
+	}{
+		{line: "Downloading  45% |████      |", want: 45, wantOk: true},
+		{line: "Uploading 12.5 %", want: 12.5, wantOk: true},
+		{line: "Copying 3/12 files", want: 25, wantOk: true},
+		{line: "[=====>    ] 50% 5/10", want: 50, wantOk: true},
+		{line: "Resolving 0/0", wantOk: false},
+		{line: "Downloading...", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, ok := progressOf(tt.line)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMimic_Progress(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(50 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Tty().WriteString("Downloading  10%\rDownloading  \x1b[32m60%\x1b[0m")
+	assert.NoError(t, err)
+
+	percent, ok := m.Progress("Downloading")
+	assert.True(t, ok)
+	assert.Equal(t, float64(60), percent, "only the latest frame should be read")
+
+	_, ok = m.Progress("Uploading")
+	assert.False(t, ok)
+}
+
+func TestMimic_ExpectProgressComplete(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(100 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	go func() {
+		for i := 0; i <= 100; i += 25 {
+			_, _ = m.Tty().WriteString(fmt.Sprintf("\rDownloading %3d%%", i))
+			time.Sleep(10 * time.Millisecond)
+		}
+		_, _ = m.Tty().WriteString("\r\nInstalling...")
+		time.Sleep(10 * time.Millisecond)
+		_, _ = m.Tty().WriteString("\rInstalling... done\r\n")
+	}()
+
+	line, err := m.ExpectProgressComplete("Downloading")
+	assert.NoError(t, err)
+	assert.Equal(t, "Downloading 100%", line)
+
+	line, err = m.ExpectProgressComplete("Installing")
+	assert.NoError(t, err)
+	assert.Equal(t, "Installing... done", line)
+}
+
+func TestMimic_ExpectProgressComplete_incomplete(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(50 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Tty().WriteString("Downloading  20%\rDownloading  45%\r\nerror: connection reset\r\n")
+	assert.NoError(t, err)
+
+	line, err := m.ExpectProgressComplete("Downloading")
+	assert.ErrorIs(t, err, ErrExpectTimeout)
+	assert.ErrorContains(t, err, `"Downloading  45%"`)
+	assert.Equal(t, "Downloading  45%", line)
+}
@@ -0,0 +1,56 @@
+//go:build !js
+
+package mimic
+
+import (
+	"errors"
+	"io"
+	"log"
+	"os"
+
+	creakpty "github.com/creack/pty"
+	"github.com/jimschubert/mimic/internal/expect"
+)
+
+// ptySupported reports whether pseudo terminals can be opened on this platform. If not, terminals are always emulated
+// in memory (see WithInMemoryPty).
+const ptySupported = true
+
+// openPty opens a pseudo terminal, returning its master and slave (i.e. tty)
+func openPty() (pty, tty *os.File, err error) {
+	return creakpty.Open()
+}
+
+// newPtyConsole creates a go-expect console upon pty, returning it along with the device the program under test uses
+func newPtyConsole(stdIn []io.Reader, stdOut []io.Writer, pty, tty *os.File, logger *log.Logger) (console, io.ReadWriteCloser, error) {
+	consoleOptions := make([]expect.ConsoleOpt, 0)
+	consoleOptions = append(consoleOptions, expect.WithStdin(stdIn...))
+	consoleOptions = append(consoleOptions, expect.WithStdout(stdOut...))
+	consoleOptions = append(consoleOptions, expect.WithCloser(pty, tty))
+	if logger != nil {
+		consoleOptions = append(consoleOptions, expect.WithLogger(logger))
+	}
+
+	c, err := expect.NewConsole(consoleOptions...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, c.Tty(), nil
+}
+
+// setPtySize sets the window size of the pseudo terminal of tty
+func setPtySize(tty *os.File, rows, columns int) error {
+	return creakpty.Setsize(tty, &creakpty.Winsize{Rows: uint16(rows), Cols: uint16(columns)})
+}
+
+// Console provides access to the underlying expect.Console
+func (e exp) Console() (expect.Console, error) {
+	if e.console == nil {
+		return expect.Console{}, errors.New("console is uninitialized")
+	}
+	c, ok := e.console.(*expect.Console)
+	if !ok {
+		return expect.Console{}, ErrNoPty
+	}
+	return *c, nil
+}
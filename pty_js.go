@@ -0,0 +1,31 @@
+//go:build js
+
+package mimic
+
+import (
+	"io"
+	"log"
+	"os"
+
+	"github.com/jimschubert/mimic/internal/expect"
+)
+
+// ptySupported is false, as there are no pseudo terminals under js/wasm
+const ptySupported = false
+
+func openPty() (pty, tty *os.File, err error) {
+	return nil, nil, ErrNoPty
+}
+
+func newPtyConsole(stdIn []io.Reader, stdOut []io.Writer, pty, tty *os.File, logger *log.Logger) (console, io.ReadWriteCloser, error) {
+	return nil, nil, ErrNoPty
+}
+
+func setPtySize(tty *os.File, rows, columns int) error {
+	return ErrNoPty
+}
+
+// Console returns ErrNoPty, as there's no expect.Console under js/wasm
+func (e exp) Console() (expect.Console, error) {
+	return expect.Console{}, ErrNoPty
+}
@@ -0,0 +1,253 @@
+package mimic
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Defaults for QueryResponses
+const (
+	// DefaultDeviceAttributes identifies the emulated terminal as a VT220 with ANSI color
+	DefaultDeviceAttributes = "\x1b[?62;22c"
+	// DefaultForeground is the foreground color reported by the emulated terminal
+	DefaultForeground = "#ffffff"
+	// DefaultBackground is the background color reported by the emulated terminal
+	DefaultBackground = "#000000"
+)
+
+// QueryResponses configures the emulated terminal's answers to queries which applications send to discover its
+// capabilities. Without answers, applications which wait for them hang or time out. Empty fields take their defaults.
+// See WithQueryResponses.
+type QueryResponses struct {
+	// DeviceAttributes is the reply to a primary device attributes (DA1) query, ESC [ c. Defaults to
+	// DefaultDeviceAttributes.
+	DeviceAttributes string
+	// Foreground and Background are the colors reported for OSC 10 and OSC 11 queries, formatted as "#rrggbb". They
+	// default to DefaultForeground and DefaultBackground, a dark theme.
+	Foreground string
+	Background string
+	// Capabilities are the values of terminfo capabilities reported for XTGETTCAP queries, DCS + q, by name (e.g. "TN"
+	// or "Co"). They take precedence over the defaults, which describe an xterm-256color terminal.
+	Capabilities map[string]string
+	// CursorPosition, if set, determines the position reported for cursor position (CPR) queries, ESC [ 6 n, from the
+	// zero-based row and column of the emulated terminal's cursor. By default, the cursor's position is reported.
+	CursorPosition func(row, column int) (reportedRow, reportedColumn int)
+}
+
+// defaultCapabilities are reported for XTGETTCAP queries unless overridden via QueryResponses.Capabilities
+var defaultCapabilities = map[string]string{
+	"TN":     "xterm-256color",
+	"Co":     "256",
+	"colors": "256",
+}
+
+// WithQueryResponses configures answers to terminal capability queries. The emulated terminal answers device status
+// reports (DSR), cursor position reports (CPR), primary device attributes (DA1), foreground and background color
+// queries (OSC 10 and OSC 11), and XTGETTCAP queries without this option; it allows customizing the answers, e.g. to
+// test how an application adapts to a light background:
+//
+//	mimic.WithQueryResponses(mimic.QueryResponses{Background: "#ffffff", Foreground: "#000000"})
+func WithQueryResponses(responses QueryResponses) Option {
+	return func(opt *mimicOpt) {
+		opt.queryResponses = responses
+	}
+}
+
+// withDefaults fills empty fields of r with defaults
+func (r QueryResponses) withDefaults() QueryResponses {
+	if r.DeviceAttributes == "" {
+		r.DeviceAttributes = DefaultDeviceAttributes
+	}
+	if r.Foreground == "" {
+		r.Foreground = DefaultForeground
+	}
+	if r.Background == "" {
+		r.Background = DefaultBackground
+	}
+	capabilities := make(map[string]string, len(defaultCapabilities)+len(r.Capabilities))
+	for name, value := range defaultCapabilities {
+		capabilities[name] = value
+	}
+	for name, value := range r.Capabilities {
+		capabilities[name] = value
+	}
+	r.Capabilities = capabilities
+	return r
+}
+
+// responder answers queries in output, writing replies as input to the program
+type responder struct {
+	mu        sync.Mutex
+	parser    controlParser
+	replies   io.Writer
+	responses QueryResponses
+	handlers  *queryHandlers
+}
+
+func (r *responder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, b := range p {
+		if ctl, ok := r.parser.feed(b); ok {
+			if reply := r.answer(ctl); len(reply) > 0 {
+				_, _ = r.replies.Write(reply)
+			}
+		}
+	}
+	return len(p), nil
+}
+
+// answer returns the reply to a query, or nil if ctl isn't a query the responder answers. Status and cursor position
+// reports are answered by the terminal itself, via terminalReplies.
+func (r *responder) answer(ctl control) []byte {
+	if ctl.kind == '[' && (string(ctl.payload) == "5n" || string(ctl.payload) == "6n") {
+		return nil
+	}
+	if reply, ok := r.handlers.reply(ctl.sequence()); ok {
+		return reply
+	}
+	switch {
+	case ctl.kind == '[' && (string(ctl.payload) == "c" || string(ctl.payload) == "0c"):
+		return []byte(r.responses.DeviceAttributes)
+	case ctl.kind == ']' && string(ctl.payload) == "10;?":
+		return []byte(colorReport(10, r.responses.Foreground))
+	case ctl.kind == ']' && string(ctl.payload) == "11;?":
+		return []byte(colorReport(11, r.responses.Background))
+	case ctl.is('P', []byte("+q")):
+		return []byte(r.capabilities(string(ctl.payload[2:])))
+	}
+	return nil
+}
+
+// capabilities answers an XTGETTCAP query for hex-encoded capability names separated by semicolons
+func (r *responder) capabilities(query string) string {
+	var sb strings.Builder
+	for _, encoded := range strings.Split(query, ";") {
+		name, err := hex.DecodeString(encoded)
+		value, known := r.responses.Capabilities[string(name)]
+		if err != nil || !known {
+			_, _ = fmt.Fprintf(&sb, "\x1bP0+r%s\x1b\\", encoded)
+			continue
+		}
+		_, _ = fmt.Fprintf(&sb, "\x1bP1+r%s=%s\x1b\\", encoded, hex.EncodeToString([]byte(value)))
+	}
+	return sb.String()
+}
+
+// colorReport formats the reply to an OSC color query for a "#rrggbb" color, or returns an empty string for an
+// invalid color
+func colorReport(number int, color string) string {
+	var red, green, blue uint8
+	if _, err := fmt.Sscanf(color, "#%02x%02x%02x", &red, &green, &blue); err != nil || len(color) != 7 {
+		return ""
+	}
+	return fmt.Sprintf("\x1b]%d;rgb:%02x%02x/%02x%02x/%02x%02x\a", number, red, red, green, green, blue, blue)
+}
+
+// terminalReplies filters the replies written by the emulated terminal itself: color reports are answered by the
+// responder instead, status and cursor position reports may be replaced by handlers registered via Mimic.HandleQuery,
+// and cursor position reports are adjusted by QueryResponses.CursorPosition
+type terminalReplies struct {
+	w              io.Writer
+	cursorPosition func(row, column int) (int, int)
+	handlers       *queryHandlers
+}
+
+func (t *terminalReplies) Write(p []byte) (int, error) {
+	if bytes.HasPrefix(p, []byte("\x1b]10;")) || bytes.HasPrefix(p, []byte("\x1b]11;")) {
+		return len(p), nil
+	}
+	if query := statusQuery(p); query != "" {
+		if reply, ok := t.handlers.reply(query); ok {
+			_, err := t.w.Write(reply)
+			return len(p), err
+		}
+	}
+	var row, column int
+	if t.cursorPosition != nil {
+		if _, err := fmt.Sscanf(string(p), "\x1b[%d;%dR", &row, &column); err == nil {
+			row, column = t.cursorPosition(row-1, column-1)
+			_, err = fmt.Fprintf(t.w, "\x1b[%d;%dR", row+1, column+1)
+			return len(p), err
+		}
+	}
+	return t.w.Write(p)
+}
+
+// statusQuery returns the query answered by reply, a reply written by the emulated terminal, if it's a status or cursor
+// position report
+func statusQuery(reply []byte) string {
+	switch {
+	case string(reply) == "\x1b[0n":
+		return "\x1b[5n"
+	case bytes.HasPrefix(reply, []byte("\x1b[")) && bytes.HasSuffix(reply, []byte("R")):
+		return "\x1b[6n"
+	}
+	return ""
+}
+
+// QueryHandler returns the reply to a query registered via Mimic.HandleQuery, or nil to leave the query to handlers
+// registered later and the built-in answers. An empty, non-nil reply suppresses any answer.
+type QueryHandler func(query string) []byte
+
+type queryHandler struct {
+	pattern *regexp.Regexp
+	handle  QueryHandler
+}
+
+type queryHandlers struct {
+	mu       sync.RWMutex
+	handlers []queryHandler
+}
+
+func (q *queryHandlers) add(pattern *regexp.Regexp, handler QueryHandler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers = append(q.handlers, queryHandler{pattern: pattern, handle: handler})
+}
+
+// reply returns the reply of the first handler whose pattern matches query and which doesn't decline to answer it
+func (q *queryHandlers) reply(query string) ([]byte, bool) {
+	q.mu.RLock()
+	handlers := make([]queryHandler, len(q.handlers))
+	copy(handlers, q.handlers)
+	q.mu.RUnlock()
+
+	for _, h := range handlers {
+		if !h.pattern.MatchString(query) {
+			continue
+		}
+		if reply := h.handle(query); reply != nil {
+			return reply, true
+		}
+	}
+	return nil, false
+}
+
+// HandleQuery registers a handler for escape sequence queries matching pattern, a regular expression which must match
+// the whole query. This allows emulating specific terminals, e.g. answering iTerm2's proprietary queries:
+//
+//	_ = console.HandleQuery(`\x1b\]1337;ReportCellSize\x1b\\`, func(string) []byte {
+//		return []byte("\x1b]1337;ReportCellSize=17.0;8.0;2.0\x1b\\")
+//	})
+//
+// Queries are matched as written, except that control strings (OSC, DCS, APC, etc.) are always terminated with ST,
+// ESC \, even if the program terminated them with BEL. Handlers are consulted in the order they were registered, and
+// take precedence over the answers configured via WithQueryResponses. The reply is written as input to the program.
+func (m *Mimic) HandleQuery(pattern string, handler QueryHandler) error {
+	if handler == nil {
+		return errors.New("query handler must not be nil")
+	}
+	re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+	if err != nil {
+		return fmt.Errorf("invalid query pattern %q: %w", pattern, err)
+	}
+	m.queries.add(re, handler)
+	return nil
+}
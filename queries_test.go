@@ -0,0 +1,82 @@
+package mimic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// queryReply writes query as the program would, and returns the terminal's reply
+func queryReply(t *testing.T, m *Mimic, query string) string {
+	t.Helper()
+	_, err := m.Device().Write([]byte(query))
+	assert.NoError(t, err)
+	assert.NoError(t, m.Flush())
+	buf := make([]byte, 1024)
+	n, err := m.Device().Read(buf)
+	assert.NoError(t, err)
+	return string(buf[:n])
+}
+
+func TestQueryResponses_defaults(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	assert.Equal(t, DefaultDeviceAttributes, queryReply(t, m, "\x1b[c"))
+	assert.Equal(t, "\x1b]11;rgb:0000/0000/0000\a", queryReply(t, m, "\x1b]11;?\x1b\\"))
+	assert.Equal(t, "\x1b[1;6R", queryReply(t, m, "hello\x1b[6n"))
+	// "TN" and "xx", hex-encoded
+	assert.Equal(t, "\x1bP1+r544e=787465726d2d323536636f6c6f72\x1b\\\x1bP0+r7878\x1b\\", queryReply(t, m, "\x1bP+q544e;7878\x1b\\"))
+}
+
+func TestWithQueryResponses(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithIdleTimeout(100*time.Millisecond), WithQueryResponses(QueryResponses{
+		DeviceAttributes: "\x1b[?1;2c",
+		Background:       "#fdf6e3",
+		Capabilities:     map[string]string{"TN": "kitty"},
+		CursorPosition: func(row, column int) (int, int) {
+			return 9, 19
+		},
+	}))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	assert.Equal(t, "\x1b[?1;2c", queryReply(t, m, "\x1b[0c"))
+	assert.Equal(t, "\x1b]11;rgb:fdfd/f6f6/e3e3\a", queryReply(t, m, "\x1b]11;?\a"))
+	assert.Equal(t, "\x1b]10;rgb:ffff/ffff/ffff\a", queryReply(t, m, "\x1b]10;?\a"), "unset colors should take their defaults")
+	assert.Equal(t, "\x1b[10;20R", queryReply(t, m, "\x1b[6n"))
+	assert.Equal(t, "\x1bP1+r544e=6b69747479\x1b\\", queryReply(t, m, "\x1bP+q544e\x1b\\"))
+}
+
+func TestMimic_HandleQuery(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	var queries []string
+	assert.NoError(t, m.HandleQuery(`\x1b\]1337;ReportCellSize\x1b\\`, func(query string) []byte {
+		queries = append(queries, query)
+		return []byte("\x1b]1337;ReportCellSize=17.0;8.0\x1b\\")
+	}))
+	assert.NoError(t, m.HandleQuery(`\x1b\[>0?c`, func(string) []byte {
+		return []byte("\x1b[>1;4000;0c")
+	}))
+	assert.NoError(t, m.HandleQuery(`\x1b\[0?c`, func(string) []byte {
+		return nil
+	}))
+	assert.NoError(t, m.HandleQuery(`\x1b\[6n`, func(string) []byte {
+		return []byte("\x1b[3;4R")
+	}))
+
+	assert.Equal(t, "\x1b]1337;ReportCellSize=17.0;8.0\x1b\\", queryReply(t, m, "\x1b]1337;ReportCellSize\a"))
+	assert.Equal(t, []string{"\x1b]1337;ReportCellSize\x1b\\"}, queries, "control strings should be terminated with ST")
+	assert.Equal(t, "\x1b[>1;4000;0c", queryReply(t, m, "\x1b[>c"))
+	assert.Equal(t, DefaultDeviceAttributes, queryReply(t, m, "\x1b[c"), "a declining handler should leave the built-in answer")
+	assert.Equal(t, "\x1b[3;4R", queryReply(t, m, "\x1b[6n"), "handlers should replace the terminal's own reports")
+	assert.Equal(t, "\x1b[0n", queryReply(t, m, "\x1b[5n"))
+
+	assert.Error(t, m.HandleQuery(`\x1b[`, func(string) []byte { return nil }))
+	assert.Error(t, m.HandleQuery(`x`, nil))
+}
@@ -0,0 +1,82 @@
+package mimic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SelectIndex answers a selection prompt (e.g. survey.Select) by navigating down to the zero-based option index,
+// assuming the first option is initially highlighted.
+type SelectIndex int
+
+// AnswerType constrains the types of answers which can be provided to a Question.
+type AnswerType interface {
+	string | int | bool | SelectIndex
+}
+
+// Question pairs a prompt with the key sequence which answers it. Construct via Ask.
+type Question struct {
+	prompt string
+	keys   string
+	echo   string
+}
+
+// Ask creates a Question answered with a typed value, which is converted into the appropriate key sequence:
+//
+//   - string and int answers are typed, followed by enter
+//   - bool answers type "y" or "n", followed by enter
+//   - SelectIndex answers press the down arrow once per index, followed by enter
+//
+// String and int answers are expected to be echoed back by the application. Use Question.Echoes to
+// validate the echoed value of other answer types.
+func Ask[T AnswerType](prompt string, answer T) Question {
+	q := Question{prompt: prompt}
+	switch v := any(answer).(type) {
+	case string:
+		q.keys = v + string(KeyEnter)
+		q.echo = v
+	case int:
+		q.keys = strconv.Itoa(v) + string(KeyEnter)
+		q.echo = strconv.Itoa(v)
+	case bool:
+		if v {
+			q.keys = "y" + string(KeyEnter)
+		} else {
+			q.keys = "n" + string(KeyEnter)
+		}
+	case SelectIndex:
+		q.keys = strings.Repeat(string(KeyArrowDown), int(v)) + string(KeyEnter)
+	}
+	return q
+}
+
+// Echoes sets the text the application is expected to display after the question has been answered, such as the label
+// of a selected option. An empty value disables validation.
+func (q Question) Echoes(text string) Question {
+	q.echo = text
+	return q
+}
+
+// Answer walks through each question in order: waiting for its prompt, sending its answer, then waiting for the
+// answer to be echoed. The returned error identifies the question which failed.
+func (m *Mimic) Answer(questions ...Question) error {
+	for i, q := range questions {
+		if err := m.ExpectString(q.prompt); err != nil {
+			return fmt.Errorf("question %d (%q): prompt not displayed: %w", i+1, q.prompt, err)
+		}
+
+		if _, err := m.WriteString(q.keys); err != nil {
+			return fmt.Errorf("question %d (%q): unable to send answer: %w", i+1, q.prompt, err)
+		}
+
+		if q.echo == "" {
+			continue
+		}
+
+		if err := m.ExpectString(q.echo); err != nil {
+			return fmt.Errorf("question %d (%q): answer %q not displayed: %w", i+1, q.prompt, q.echo, err)
+		}
+	}
+	return nil
+}
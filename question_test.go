@@ -0,0 +1,59 @@
+package mimic
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_Answer(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	colors := []string{"Red", "Green", "Blue"}
+	answers := make(chan string, 4)
+	go func() {
+		reader := bufio.NewReader(m.Tty())
+		for _, prompt := range []string{"What is your name? ", "How old are you? ", "Continue? ", "Favorite color? "} {
+			_, _ = m.Tty().WriteString(prompt)
+			line, _ := reader.ReadString('\n')
+			line = strings.TrimSpace(line)
+			if prompt == "Favorite color? " {
+				line = colors[strings.Count(line, "\x1b[B")]
+				_, _ = fmt.Fprintf(m.Tty(), "\r\nSelected %s\r\n", line)
+			}
+			answers <- line
+		}
+	}()
+
+	err = m.Answer(
+		Ask("What is your name?", "Tom"),
+		Ask("How old are you?", 20),
+		Ask("Continue?", true),
+		Ask("Favorite color?", SelectIndex(2)).Echoes("Selected Blue"),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "Tom", <-answers)
+	assert.Equal(t, "20", <-answers)
+	assert.Equal(t, "y", <-answers)
+	assert.Equal(t, "Blue", <-answers)
+}
+
+func TestMimic_Answer_identifiesFailedQuestion(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(50 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, _ = m.Tty().WriteString("What is your name? ")
+
+	err = m.Answer(
+		Ask("What is your name?", "Tom"),
+		Ask("How old are you?", 20),
+	)
+	assert.ErrorContains(t, err, `question 2 ("How old are you?"): prompt not displayed`)
+}
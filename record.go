@@ -0,0 +1,254 @@
+package mimic
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// WithRecording records the terminal session to w, by default as an asciinema v2 cast
+// (https://docs.asciinema.org/manual/asciicast/v2/) which can be replayed via `asciinema play`. Output, input sent via
+// Mimic.Write and friends, and resizes via Mimic.Resize are recorded, as far as the format allows.
+// See WithTtyrec and WithTypescript for alternative formats.
+//
+// Output is timestamped as mimic reads it from the pty (e.g. during expectations or Mimic.Flush), rather than when the
+// application wrote it. Recording stops at the first error writing to w.
+func WithRecording(w io.Writer, opts ...RecordingOption) Option {
+	return func(opt *mimicOpt) {
+		r := &recordingOpt{w: w, format: func(w io.Writer) recorder {
+			return &asciicastRecorder{w: w}
+		}}
+		for _, o := range opts {
+			o(r)
+		}
+		opt.recording = r
+	}
+}
+
+// RecordingOption configures a recording created via WithRecording
+type RecordingOption func(*recordingOpt)
+
+type recordingOpt struct {
+	w      io.Writer
+	format func(w io.Writer) recorder
+}
+
+// WithTtyrec records in the ttyrec format, as replayed by ttyplay and consumed by tools such as termtosvg.
+// ttyrec records output only.
+func WithTtyrec() RecordingOption {
+	return func(opt *recordingOpt) {
+		opt.format = func(w io.Writer) recorder {
+			return &ttyrecRecorder{w: w}
+		}
+	}
+}
+
+// WithTypescript records output as a script(1) typescript, writing timing data to timing so that the session can be
+// replayed via `scriptreplay --timing <timing> <typescript>`. A typescript records output only.
+func WithTypescript(timing io.Writer) RecordingOption {
+	return func(opt *recordingOpt) {
+		opt.format = func(w io.Writer) recorder {
+			return &typescriptRecorder{w: w, timing: timing}
+		}
+	}
+}
+
+// recorder encodes the events of a terminal session in a recording format
+type recorder interface {
+	start(at time.Time, rows, columns int) error
+	output(elapsed time.Duration, p []byte) error
+	input(elapsed time.Duration, p []byte) error
+	resize(elapsed time.Duration, rows, columns int) error
+}
+
+// recording timestamps session events for a recorder. Output is written as it's read, a rune at a time, so it's
+// accumulated until the read ends (see recording.flush) and recorded as a single event, timestamped as of its first byte.
+type recording struct {
+	mu       sync.Mutex
+	recorder recorder
+	started  time.Time
+	failed   bool
+	pending  []byte
+	at       time.Duration
+}
+
+func newRecording(r recorder, rows, columns int) *recording {
+	rec := &recording{recorder: r, started: time.Now()}
+	rec.record(func(time.Duration) error {
+		return r.start(rec.started, rows, columns)
+	})
+	return rec
+}
+
+func (r *recording) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.pending) == 0 {
+		r.at = time.Since(r.started)
+	}
+	r.pending = append(r.pending, p...)
+	return len(p), nil
+}
+
+// flush records the output accumulated during a read
+func (r *recording) flush() {
+	r.record(func(time.Duration) error {
+		return nil
+	})
+}
+
+func (r *recording) input(p []byte) {
+	r.record(func(elapsed time.Duration) error {
+		return r.recorder.input(elapsed, p)
+	})
+}
+
+func (r *recording) resize(rows, columns int) {
+	r.record(func(elapsed time.Duration) error {
+		return r.recorder.resize(elapsed, rows, columns)
+	})
+}
+
+// record records pending output, then event, unless recording has failed
+func (r *recording) record(event func(elapsed time.Duration) error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pending := r.pending
+	r.pending = nil
+	if r.failed {
+		return
+	}
+	if len(pending) > 0 {
+		if err := r.recorder.output(r.at, pending); err != nil {
+			r.failed = true
+			return
+		}
+	}
+	if err := event(time.Since(r.started)); err != nil {
+		r.failed = true
+	}
+}
+
+// asciicastRecorder writes asciinema v2 cast files
+type asciicastRecorder struct {
+	w io.Writer
+	// pending holds the incomplete UTF-8 sequence at the end of the previous output, as events must be valid UTF-8
+	pending []byte
+}
+
+type asciicastHeader struct {
+	Version   int   `json:"version"`
+	Width     int   `json:"width"`
+	Height    int   `json:"height"`
+	Timestamp int64 `json:"timestamp"`
+}
+
+func (a *asciicastRecorder) start(at time.Time, rows, columns int) error {
+	return a.write(asciicastHeader{Version: 2, Width: columns, Height: rows, Timestamp: at.Unix()})
+}
+
+func (a *asciicastRecorder) output(elapsed time.Duration, p []byte) error {
+	data := append(a.pending, p...)
+	end := len(data)
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				end = i
+			}
+			break
+		}
+	}
+	a.pending = append([]byte(nil), data[end:]...)
+	if end == 0 {
+		return nil
+	}
+	return a.event(elapsed, "o", string(data[:end]))
+}
+
+func (a *asciicastRecorder) input(elapsed time.Duration, p []byte) error {
+	return a.event(elapsed, "i", string(p))
+}
+
+func (a *asciicastRecorder) resize(elapsed time.Duration, rows, columns int) error {
+	return a.event(elapsed, "r", fmt.Sprintf("%dx%d", columns, rows))
+}
+
+func (a *asciicastRecorder) event(elapsed time.Duration, code string, data string) error {
+	return a.write([]interface{}{elapsed.Round(time.Microsecond).Seconds(), code, data})
+}
+
+func (a *asciicastRecorder) write(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = a.w.Write(append(b, '\n'))
+	return err
+}
+
+// typescriptRecorder writes raw output as script(1) does, along with timing data compatible with scriptreplay(1):
+// lines of the delay in seconds since the previous output, followed by the output's length in bytes
+type typescriptRecorder struct {
+	w, timing io.Writer
+	last      time.Duration
+}
+
+func (t *typescriptRecorder) start(at time.Time, _, _ int) error {
+	// scriptreplay skips the first line of the typescript, which script(1) uses for this header
+	_, err := fmt.Fprintf(t.w, "Script started on %s\n", at.Format(time.RFC3339))
+	return err
+}
+
+func (t *typescriptRecorder) output(elapsed time.Duration, p []byte) error {
+	if _, err := fmt.Fprintf(t.timing, "%.6f %d\n", (elapsed - t.last).Seconds(), len(p)); err != nil {
+		return err
+	}
+	t.last = elapsed
+	_, err := t.w.Write(p)
+	return err
+}
+
+func (t *typescriptRecorder) input(time.Duration, []byte) error {
+	return nil
+}
+
+func (t *typescriptRecorder) resize(time.Duration, int, int) error {
+	return nil
+}
+
+// ttyrecRecorder writes ttyrec recordings: a sequence of frames, each a header of little-endian uint32 seconds,
+// microseconds, and length, followed by the output
+type ttyrecRecorder struct {
+	w       io.Writer
+	started time.Time
+}
+
+func (t *ttyrecRecorder) start(at time.Time, _, _ int) error {
+	t.started = at
+	return nil
+}
+
+func (t *ttyrecRecorder) output(elapsed time.Duration, p []byte) error {
+	at := t.started.Add(elapsed)
+	frame := make([]byte, 12, 12+len(p))
+	binary.LittleEndian.PutUint32(frame[0:], uint32(at.Unix()))
+	binary.LittleEndian.PutUint32(frame[4:], uint32(at.Nanosecond()/int(time.Microsecond)))
+	binary.LittleEndian.PutUint32(frame[8:], uint32(len(p)))
+	_, err := t.w.Write(append(frame, p...))
+	return err
+}
+
+func (t *ttyrecRecorder) input(time.Duration, []byte) error {
+	return nil
+}
+
+func (t *ttyrecRecorder) resize(time.Duration, int, int) error {
+	return nil
+}
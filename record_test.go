@@ -0,0 +1,156 @@
+package mimic
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// castEvents parses an asciinema v2 cast into its header and events
+func castEvents(t *testing.T, cast string) (map[string]interface{}, [][]interface{}) {
+	t.Helper()
+	lines := strings.Split(strings.TrimSpace(cast), "\n")
+	var header map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &header))
+
+	var events [][]interface{}
+	for _, line := range lines[1:] {
+		var event []interface{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &event))
+		events = append(events, event)
+	}
+	return header, events
+}
+
+func TestWithRecording(t *testing.T) {
+	var cast bytes.Buffer
+	m, err := NewMimic(WithSize(5, 20), WithRecording(&cast))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, _ = m.Tty().WriteString("héllo ")
+	assert.NoError(t, m.Flush())
+	_, err = m.WriteString("ok\r")
+	assert.NoError(t, err)
+	assert.NoError(t, m.ExpectString("ok"))
+	assert.NoError(t, m.Resize(6, 30))
+
+	header, events := castEvents(t, cast.String())
+	assert.Equal(t, float64(2), header["version"])
+	assert.Equal(t, float64(20), header["width"])
+	assert.Equal(t, float64(5), header["height"])
+
+	var output, input []string
+	var resizes []string
+	last := 0.0
+	for _, event := range events {
+		assert.GreaterOrEqual(t, event[0].(float64), last, "events should be ordered by time")
+		last = event[0].(float64)
+		switch event[1] {
+		case "o":
+			output = append(output, event[2].(string))
+		case "i":
+			input = append(input, event[2].(string))
+		case "r":
+			resizes = append(resizes, event[2].(string))
+		}
+	}
+	assert.Equal(t, "héllo ok", strings.TrimSpace(strings.Join(output, "")))
+	assert.Equal(t, []string{"ok\r"}, input)
+	assert.Equal(t, []string{"30x6"}, resizes)
+}
+
+func TestWithRecording_coalesced(t *testing.T) {
+	var cast bytes.Buffer
+	m, err := NewMimic(WithRecording(&cast))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, _ = m.Tty().WriteString("hello world")
+	assert.NoError(t, m.ExpectString("hello world"))
+
+	_, events := castEvents(t, cast.String())
+	assert.Equal(t, [][]interface{}{{events[0][0], "o", "hello world"}}, events, "a read should be recorded as a single event")
+}
+
+func TestAsciicastRecorder_splitRunes(t *testing.T) {
+	var cast bytes.Buffer
+	r := &asciicastRecorder{w: &cast}
+
+	snowman := []byte("☃")
+	assert.NoError(t, r.output(time.Millisecond, append([]byte("a"), snowman[:2]...)))
+	assert.NoError(t, r.output(2*time.Millisecond, snowman[2:]))
+
+	assert.Equal(t, "[0.001,\"o\",\"a\"]\n[0.002,\"o\",\"☃\"]\n", cast.String())
+}
+
+func TestWithRecording_ttyrec(t *testing.T) {
+	var recording bytes.Buffer
+	before := time.Now().Unix()
+	m, err := NewMimic(WithRecording(&recording, WithTtyrec()))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, _ = m.Tty().WriteString("first")
+	assert.NoError(t, m.Flush())
+	_, _ = m.Tty().WriteString(" second")
+	assert.NoError(t, m.Flush())
+
+	var output []byte
+	frames := 0
+	data := recording.Bytes()
+	for len(data) > 0 {
+		frames++
+		assert.GreaterOrEqual(t, len(data), 12, "frame header should be complete")
+		seconds := binary.LittleEndian.Uint32(data[0:])
+		micros := binary.LittleEndian.Uint32(data[4:])
+		length := binary.LittleEndian.Uint32(data[8:])
+		assert.GreaterOrEqual(t, int64(seconds), before)
+		assert.Less(t, micros, uint32(1000000))
+		output = append(output, data[12:12+length]...)
+		data = data[12+length:]
+	}
+	assert.Equal(t, "first second", string(output))
+	assert.Equal(t, 2, frames, "each read should be recorded as a single frame")
+}
+
+func TestWithRecording_typescript(t *testing.T) {
+	var typescript, timing bytes.Buffer
+	m, err := NewMimic(WithRecording(&typescript, WithTypescript(&timing)))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, _ = m.Tty().WriteString("first")
+	assert.NoError(t, m.Flush())
+	time.Sleep(20 * time.Millisecond)
+	_, _ = m.Tty().WriteString(" second")
+	assert.NoError(t, m.Flush())
+
+	header, output, _ := strings.Cut(typescript.String(), "\n")
+	assert.True(t, strings.HasPrefix(header, "Script started on "))
+	assert.Equal(t, "first second", output)
+
+	total := 0
+	longest := 0.0
+	lines := strings.Split(strings.TrimSpace(timing.String()), "\n")
+	assert.Len(t, lines, 2, "each read should be timed as a single chunk")
+	for _, line := range lines {
+		var delay float64
+		var length int
+		_, err := fmt.Sscanf(line, "%f %d", &delay, &length)
+		assert.NoError(t, err)
+		if delay > longest {
+			longest = delay
+		}
+		total += length
+	}
+	assert.Equal(t, len(output), total, "timing should account for every byte of output")
+	assert.GreaterOrEqual(t, longest, 0.02, "the pause between writes should be recorded")
+	assert.Less(t, longest, 1.0, "delays should be relative to the previous output")
+}
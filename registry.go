@@ -0,0 +1,149 @@
+package mimic
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	placeholderPattern = regexp.MustCompile(`\{\{\s*([a-zA-Z][a-zA-Z0-9_-]*)\s*}}`)
+	matcherNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+	registry = newMatcherRegistry(map[string]string{
+		"semver":   `v?(?:0|[1-9]\d*)\.(?:0|[1-9]\d*)\.(?:0|[1-9]\d*)(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?`,
+		"uuid":     `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`,
+		"duration": `-?(?:\d+(?:\.\d+)?(?:ns|us|µs|ms|s|m|h))+`,
+	})
+)
+
+type matcherRegistry struct {
+	mu       sync.RWMutex
+	patterns map[string]string
+}
+
+func newMatcherRegistry(builtins map[string]string) *matcherRegistry {
+	r := &matcherRegistry{patterns: make(map[string]string)}
+	for name, pattern := range builtins {
+		r.patterns[name] = pattern
+	}
+	return r
+}
+
+func (r *matcherRegistry) lookup(name string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	pattern, ok := r.patterns[name]
+	return pattern, ok
+}
+
+// RegisterMatcher registers a named pattern which can be referenced as a {{name}} placeholder in the patterns passed
+// to expectations and assertions (e.g. Mimic.ExpectPattern, Mimic.ContainsPattern), and in plain strings when enabled
+// via WithPlaceholders. Registering an existing name replaces its pattern. Built-in matchers are "semver", "uuid", and
+// "duration".
+func RegisterMatcher(name string, pattern string) error {
+	if !matcherNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid matcher name %q", name)
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("invalid pattern for matcher %q: %w", name, err)
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.patterns[name] = pattern
+	return nil
+}
+
+// UnregisterMatcher removes the named pattern registered via RegisterMatcher, after which {{name}} placeholders are
+// left untouched. Unregistering a name which isn't registered does nothing.
+func UnregisterMatcher(name string) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	delete(registry.patterns, name)
+}
+
+// expandPattern replaces registered {{name}} placeholders in a regular expression with their patterns.
+// Placeholders which don't refer to a registered matcher are left untouched.
+func expandPattern(pattern string) string {
+	if !strings.Contains(pattern, "{{") {
+		return pattern
+	}
+	return placeholderPattern.ReplaceAllStringFunc(pattern, func(placeholder string) string {
+		name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		if registered, ok := registry.lookup(name); ok {
+			return "(?:" + registered + ")"
+		}
+		return placeholder
+	})
+}
+
+// CompilePattern compiles pattern as the pattern-based functions of Mimic (e.g. Mimic.ContainsPattern) do, after
+// replacing registered {{name}} placeholders. An invalid pattern results in an error which wraps ErrInvalidPattern.
+func CompilePattern(pattern string) (*regexp.Regexp, error) {
+	re, err := compiledPatterns.compile(expandPattern(pattern))
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %v", ErrInvalidPattern, pattern, err)
+	}
+	return re, nil
+}
+
+// compilePatterns compiles each of patterns via CompilePattern, failing on the first which is invalid
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	regexes := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := CompilePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		regexes = append(regexes, re)
+	}
+	return regexes, nil
+}
+
+// regexpCriteria describes compiled regexes by their source, for reporting as the criteria of an operation. An error
+// is returned if any is nil.
+func regexpCriteria(regexes []*regexp.Regexp) ([]string, error) {
+	criteria := make([]string, 0, len(regexes))
+	for i, re := range regexes {
+		if re == nil {
+			return nil, fmt.Errorf("%w: regexp %d is nil", ErrInvalidPattern, i)
+		}
+		criteria = append(criteria, re.String())
+	}
+	return criteria, nil
+}
+
+// literalPattern converts a plain string containing registered {{name}} placeholders into a regular expression
+// which matches the string literally, aside from the placeholders. The boolean result is false if the string
+// contains no registered placeholders, in which case it should be matched as-is.
+func literalPattern(str string) (*regexp.Regexp, bool) {
+	locations := placeholderPattern.FindAllStringSubmatchIndex(str, -1)
+	if len(locations) == 0 {
+		return nil, false
+	}
+
+	var b strings.Builder
+	expanded := false
+	last := 0
+	for _, loc := range locations {
+		registered, ok := registry.lookup(str[loc[2]:loc[3]])
+		if !ok {
+			continue
+		}
+		b.WriteString(regexp.QuoteMeta(str[last:loc[0]]))
+		b.WriteString("(?:" + registered + ")")
+		last = loc[1]
+		expanded = true
+	}
+
+	if !expanded {
+		return nil, false
+	}
+
+	b.WriteString(regexp.QuoteMeta(str[last:]))
+	// registered patterns are validated by RegisterMatcher, and the remainder is quoted
+	re, err := compiledPatterns.compile(b.String())
+	return re, err == nil
+}
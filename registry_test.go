@@ -0,0 +1,63 @@
+package mimic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterMatcher(t *testing.T) {
+	assert.Error(t, RegisterMatcher("not a name", `\d+`), "names must be identifiers")
+	assert.Error(t, RegisterMatcher("broken", `(`), "patterns must compile")
+	assert.NoError(t, RegisterMatcher("ticket", `[A-Z]+-\d+`))
+	t.Cleanup(func() { UnregisterMatcher("ticket") })
+
+	assert.Equal(t, `id: (?:[A-Z]+-\d+) {{unknown}}`, expandPattern(`id: {{ticket}} {{unknown}}`))
+
+	re, ok := literalPattern("Created {{ticket}} (v{{semver}})")
+	assert.True(t, ok)
+	assert.True(t, re.MatchString("Created ABC-123 (v1.2.3-rc.1)"))
+	assert.False(t, re.MatchString("Created ABC-123 v1.2.3"), "literal portions must be escaped")
+
+	_, ok = literalPattern("no {{unknown}} placeholders")
+	assert.False(t, ok)
+
+	UnregisterMatcher("ticket")
+	assert.Equal(t, `id: {{ticket}}`, expandPattern(`id: {{ticket}}`), "unregistered placeholders should be left untouched")
+}
+
+func TestCompilePattern(t *testing.T) {
+	re, err := CompilePattern(`mimic {{semver}}`)
+	assert.NoError(t, err)
+	assert.True(t, re.MatchString("mimic v1.2.3"), "placeholders should be expanded")
+
+	_, err = CompilePattern(`mimic (`)
+	assert.ErrorIs(t, err, ErrInvalidPattern)
+	assert.ErrorContains(t, err, `"mimic ("`)
+}
+
+func TestMimic_ExpectString_placeholders(t *testing.T) {
+	m, err := NewMimic(WithPlaceholders(), WithIdleTimeout(50*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.WriteString("request 0f8fad5b-d9cb-469f-a165-70867728950e took 1.5s")
+	assert.NoError(t, err)
+
+	assert.NoError(t, m.ExpectString("request {{uuid}} took {{duration}}"))
+	assert.True(t, m.ContainsString("request {{uuid}}"))
+	assert.True(t, m.ContainsPattern(`took {{duration}}$`))
+}
+
+func TestMimic_ContainsString_literalPlaceholders(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(50 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.WriteString("template {{uuid}}")
+	assert.NoError(t, err)
+
+	assert.True(t, m.ContainsString("template {{uuid}}"), "plain strings should be matched as written by default")
+	assert.False(t, m.With(WithCallPlaceholders()).ContainsString("template {{uuid}}"))
+}
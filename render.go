@@ -0,0 +1,154 @@
+package mimic
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// colors used for DefaultColor when rendering
+const (
+	defaultForegroundHex = "#e5e5e5"
+	defaultBackgroundHex = "#000000"
+)
+
+// ansiHex are the 16 ANSI colors, per xterm's defaults
+var ansiHex = [16]string{
+	"#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+	"#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+// RenderHTML renders the emulated terminal's screen as a standalone HTML fragment: a <pre> element with inline styles
+// for colors, bold, italic, and underline, suitable for attaching to reports. Trailing blank cells of each row are
+// omitted. Pending output is flushed first.
+func RenderHTML(m *Mimic) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<pre style="background-color:%s;color:%s;font-family:monospace;line-height:1.2;padding:0.5em">`, defaultBackgroundHex, defaultForegroundHex)
+	for y, row := range m.Screen() {
+		if y > 0 {
+			sb.WriteByte('\n')
+		}
+		for _, run := range styledRuns(trimRow(row)) {
+			text := html.EscapeString(run.text)
+			if css := run.css(); css != "" {
+				fmt.Fprintf(&sb, `<span style="%s">%s</span>`, css, text)
+			} else {
+				sb.WriteString(text)
+			}
+		}
+	}
+	sb.WriteString("</pre>\n")
+	return sb.String()
+}
+
+// styledRun is consecutive text on a row which shares the same rendering attributes
+type styledRun struct {
+	// column is the zero-based column at which the run begins
+	column int
+	text   string
+	// cell holds the attributes of the run
+	cell Cell
+}
+
+func (r styledRun) foregroundHex() string {
+	return cellForegroundHex(r.cell)
+}
+
+func (r styledRun) backgroundHex() string {
+	return cellBackgroundHex(r.cell)
+}
+
+func (r styledRun) css() string {
+	var styles []string
+	if fg := r.foregroundHex(); fg != defaultForegroundHex {
+		styles = append(styles, "color:"+fg)
+	}
+	if bg := r.backgroundHex(); bg != defaultBackgroundHex {
+		styles = append(styles, "background-color:"+bg)
+	}
+	if r.cell.Bold {
+		styles = append(styles, "font-weight:bold")
+	}
+	if r.cell.Italic {
+		styles = append(styles, "font-style:italic")
+	}
+	if r.cell.Underline {
+		styles = append(styles, "text-decoration:underline")
+	}
+	return strings.Join(styles, ";")
+}
+
+// styledRuns groups a row's cells into runs of identical attributes
+func styledRuns(row []Cell) []styledRun {
+	var runs []styledRun
+	var text []rune
+	for x, cell := range row {
+		if cell.Width == 0 {
+			continue
+		}
+		attributes := cell
+		attributes.Rune, attributes.Width = 0, 0
+		if len(runs) > 0 && runs[len(runs)-1].cell == attributes {
+			text = append(text, cell.Rune)
+			continue
+		}
+		if len(runs) > 0 {
+			runs[len(runs)-1].text = string(text)
+		}
+		runs = append(runs, styledRun{column: x, cell: attributes})
+		text = []rune{cell.Rune}
+	}
+	if len(runs) > 0 {
+		runs[len(runs)-1].text = string(text)
+	}
+	return runs
+}
+
+// trimRow omits trailing blank cells which would render identically to the terminal's background
+func trimRow(row []Cell) []Cell {
+	end := len(row)
+	for end > 0 {
+		cell := row[end-1]
+		if cell.Rune != ' ' || cellBackgroundHex(cell) != defaultBackgroundHex || cell.Underline {
+			break
+		}
+		end--
+	}
+	return row[:end]
+}
+
+// cellForegroundHex resolves the rendered foreground of cell. Colors of reversed cells are already swapped, so a
+// reversed cell with DefaultColor foreground displays the default background.
+func cellForegroundHex(cell Cell) string {
+	if cell.Foreground == DefaultColor && cell.Reverse {
+		return defaultBackgroundHex
+	}
+	return colorHex(cell.Foreground, defaultForegroundHex)
+}
+
+// cellBackgroundHex resolves the rendered background of cell. See cellForegroundHex.
+func cellBackgroundHex(cell Cell) string {
+	if cell.Background == DefaultColor && cell.Reverse {
+		return defaultForegroundHex
+	}
+	return colorHex(cell.Background, defaultBackgroundHex)
+}
+
+// colorHex converts c to a CSS hex color, using def for DefaultColor. Colors beyond the 256-color palette are 24-bit RGB.
+func colorHex(c Color, def string) string {
+	switch {
+	case c == DefaultColor:
+		return def
+	case c < 16:
+		return ansiHex[c]
+	case c < 232:
+		levels := [6]int{0, 95, 135, 175, 215, 255}
+		i := int(c) - 16
+		return fmt.Sprintf("#%02x%02x%02x", levels[i/36], levels[i/6%6], levels[i%6])
+	case c < 256:
+		gray := 8 + 10*(int(c)-232)
+		return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+	default:
+		return fmt.Sprintf("#%06x", uint32(c)&0xffffff)
+	}
+}
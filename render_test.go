@@ -0,0 +1,69 @@
+package mimic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderHTML(t *testing.T) {
+	m, err := NewMimic(WithSize(3, 20))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, _ = m.Tty().WriteString("\x1b[1;31mERROR\x1b[0m <a&b>\r\n\x1b[7m ok \x1b[0m \x1b[38;5;208mo\x1b[48;2;16;32;64mx")
+
+	out := RenderHTML(m)
+	lines := strings.Split(out, "\n")
+	assert.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], "<pre "))
+	assert.Contains(t, lines[0], `<span style="color:#ff0000;font-weight:bold">ERROR</span> &lt;a&amp;b&gt;`)
+	assert.Contains(t, lines[1], `<span style="color:#000000;background-color:#e5e5e5"> ok </span> `)
+	assert.Contains(t, lines[1], `<span style="color:#ff8700">o</span><span style="color:#ff8700;background-color:#102040">x</span>`)
+	assert.Equal(t, "</pre>", lines[2])
+	assert.Equal(t, out, RenderHTML(m), "rendering should be deterministic")
+}
+
+func TestColorHex(t *testing.T) {
+	tests := []struct {
+		color Color
+		want  string
+	}{
+		{color: DefaultColor, want: "#abcdef"},
+		{color: Blue, want: "#0000ee"},
+		{color: White, want: "#ffffff"},
+		{color: Color(16), want: "#000000"},
+		{color: Color(231), want: "#ffffff"},
+		{color: Color(244), want: "#808080"},
+		{color: Color(0x102040), want: "#102040"},
+		{color: RGB(0x87, 0x5f, 0xaf), want: "#875faf"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, colorHex(tt.color, "#abcdef"))
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	m, err := NewMimic(WithSize(2, 10))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, _ = m.Tty().WriteString("\x1b[1;32mPASS\x1b[0m a<b\r\n\x1b[44m  \x1b[0m")
+
+	out := RenderSVG(m, WithSVGFontSize(10), WithSVGFontFamily("Fira Code"))
+	assert.True(t, strings.HasPrefix(out, `<svg xmlns="http://www.w3.org/2000/svg" width="60" height="24" viewBox="0 0 60 24" font-family="Fira Code" font-size="10">`))
+	assert.Contains(t, out, `<text xml:space="preserve" x="0" y="10" fill="#00ff00" textLength="24" lengthAdjust="spacingAndGlyphs" font-weight="bold">PASS</text>`)
+	assert.Contains(t, out, `<text xml:space="preserve" x="24" y="10" fill="#e5e5e5" textLength="24" lengthAdjust="spacingAndGlyphs"> a&lt;b</text>`)
+	assert.Contains(t, out, `<rect x="0" y="12" width="12" height="12" fill="#0000ee"/>`)
+	assert.NotContains(t, out, `y="22"`, "blank runs should not render text")
+	assert.True(t, strings.HasSuffix(out, "</svg>\n"))
+	assert.Equal(t, out, RenderSVG(m, WithSVGFontSize(10), WithSVGFontFamily("Fira Code")), "rendering should be deterministic")
+}
+
+func TestSvgNumber(t *testing.T) {
+	fontSize := 14.0
+	assert.Equal(t, "25.2", svgNumber(3*fontSize*0.6))
+	assert.Equal(t, "16.8", svgNumber(fontSize*1.2))
+	assert.Equal(t, "0", svgNumber(0))
+}
@@ -0,0 +1,181 @@
+package mimic
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jimschubert/mimic/internal"
+	"github.com/jimschubert/mimic/internal/vt10x"
+)
+
+// ReplayOption configures Mimic.Replay
+type ReplayOption func(*replayOpt)
+
+type replayOpt struct {
+	verify bool
+	timing bool
+}
+
+// WithReplayVerification asserts that the application's output matches the recording. Before each input is sent, and
+// once the recording ends, Mimic.Replay waits for the view to match the view at that point in the recording, failing if
+// it doesn't before the idle timeout (see WithIdleTimeout and WithCallTimeout).
+func WithReplayVerification() ReplayOption {
+	return func(opt *replayOpt) {
+		opt.verify = true
+	}
+}
+
+// WithReplayTiming paces input and resize events per the recording's timestamps. By default, events are sent as soon
+// as possible.
+func WithReplayTiming() ReplayOption {
+	return func(opt *replayOpt) {
+		opt.timing = true
+	}
+}
+
+// Replay reads an asciinema v2 cast (e.g. one created via WithRecording) from r and feeds its input events into the
+// emulated terminal, applying its resize events via Mimic.Resize. The application under test is expected to already be
+// attached to the terminal, as it was when the session was recorded.
+//
+// This allows a session to be recorded once and used as a regression test thereafter. See WithReplayVerification.
+func (m *Mimic) Replay(r io.Reader, opts ...ReplayOption) error {
+	o := &replayOpt{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("replay: unable to read header: %w", err)
+		}
+		return fmt.Errorf("replay: recording is empty")
+	}
+
+	var header asciicastHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return fmt.Errorf("replay: invalid header: %w", err)
+	}
+	if header.Version != 2 {
+		return fmt.Errorf("replay: unsupported asciicast version %d", header.Version)
+	}
+
+	// the reference terminal reproduces the recorded view, for verification, rendering output as the Mimic's terminal does
+	reference := vt10x.New(vt10x.WithSize(header.Width, header.Height))
+	output := newViewWriter(reference, reference)
+	started := time.Now()
+	for line := 2; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+
+		var event asciicastEvent
+		if err := event.UnmarshalJSON(scanner.Bytes()); err != nil {
+			return fmt.Errorf("replay: invalid event on line %d: %w", line, err)
+		}
+
+		switch event.code {
+		case "o":
+			_, _ = output.Write([]byte(event.data))
+			continue
+		case "i", "r":
+		default:
+			continue
+		}
+
+		if o.timing {
+			time.Sleep(time.Until(started.Add(event.elapsed)))
+		}
+		if o.verify {
+			if err := m.waitForView(reference, fmt.Sprintf("recording at %.3fs", event.elapsed.Seconds())); err != nil {
+				return fmt.Errorf("replay: line %d: %w", line, err)
+			}
+		}
+
+		if event.code == "i" {
+			if _, err := m.WriteString(event.data); err != nil {
+				return fmt.Errorf("replay: line %d: unable to send input: %w", line, err)
+			}
+			continue
+		}
+
+		var columns, rows int
+		if _, err := fmt.Sscanf(event.data, "%dx%d", &columns, &rows); err != nil {
+			return fmt.Errorf("replay: line %d: invalid resize %q: %w", line, event.data, err)
+		}
+		reference.Resize(columns, rows)
+		if err := m.Resize(rows, columns); err != nil {
+			return fmt.Errorf("replay: line %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("replay: %w", err)
+	}
+
+	if o.verify {
+		if err := m.waitForView(reference, "end of recording"); err != nil {
+			return fmt.Errorf("replay: %w", err)
+		}
+	}
+	return nil
+}
+
+// waitForView waits for the terminal's view to match the view of reference, returning a diff if it doesn't match
+// before the idle timeout
+func (m *Mimic) waitForView(reference vt10x.Terminal, name string) error {
+	want := trimView(strings.Split(strings.TrimSuffix(withoutSpacers(reference.String()), "\n"), "\n"))
+	if _, _, err := m.expect(context.Background(), &viewMatcher{m: m, want: want}); err != nil {
+		return fmt.Errorf("view does not match %s: %w\n%s", name, err, internal.UnifiedDiff(want, m.snapshot(), name, "Current view", m.diffColor))
+	}
+	return nil
+}
+
+// viewMatcher matches once the terminal's view matches the formatted view want, regardless of the content read
+type viewMatcher struct {
+	m    *Mimic
+	want string
+}
+
+func (v *viewMatcher) Match(_ interface{}) bool {
+	return v.m.snapshot() == v.want
+}
+
+func (v *viewMatcher) Criteria() interface{} {
+	return v.want
+}
+
+// asciicastEvent is an event of an asciinema v2 cast, encoded as [time, code, data]
+type asciicastEvent struct {
+	elapsed time.Duration
+	code    string
+	data    string
+}
+
+func (e *asciicastEvent) UnmarshalJSON(b []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	if len(fields) != 3 {
+		return fmt.Errorf("expected 3 fields, found %d", len(fields))
+	}
+
+	var seconds float64
+	if err := json.Unmarshal(fields[0], &seconds); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(fields[1], &e.code); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(fields[2], &e.data); err != nil {
+		return err
+	}
+	e.elapsed = time.Duration(seconds * float64(time.Second))
+	return nil
+}
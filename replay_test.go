@@ -0,0 +1,101 @@
+package mimic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const replayScript = `printf "name? "; read name; echo "hello $name"; printf "age? "; read age; printf "%s is %s\n" "$name" "$age"`
+
+func TestMimic_Replay(t *testing.T) {
+	var cast bytes.Buffer
+	recorded, err := NewMimic(WithIdleTimeout(2*time.Second), WithRecording(&cast))
+	assert.NoError(t, err)
+	p, err := recorded.Spawn(context.Background(), "sh", "-c", replayScript)
+	assert.NoError(t, err)
+	assert.NoError(t, recorded.Answer(Ask("name?", "Tom"), Ask("age?", 20)))
+	assert.NoError(t, p.Wait())
+	assert.NoError(t, recorded.Flush())
+	var recordedOutput strings.Builder
+	_, events := castEvents(t, cast.String())
+	for _, event := range events {
+		if event[1] == "o" {
+			recordedOutput.WriteString(event[2].(string))
+		}
+	}
+	assert.Contains(t, recordedOutput.String(), "Tom is 20")
+	assert.NoError(t, recorded.Close())
+
+	tests := []struct {
+		name    string
+		script  string
+		wantErr string
+	}{
+		{name: "matching output", script: replayScript},
+		{name: "regressed output", script: strings.Replace(replayScript, "hello", "goodbye", 1), wantErr: "+goodbye Tom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMimic(WithIdleTimeout(500 * time.Millisecond))
+			assert.NoError(t, err)
+			defer m.Close()
+
+			p, err := m.Spawn(context.Background(), "sh", "-c", tt.script)
+			assert.NoError(t, err)
+
+			err = m.Replay(bytes.NewReader(cast.Bytes()), WithReplayVerification())
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.NoError(t, p.Wait())
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestMimic_Replay_invalid(t *testing.T) {
+	m, err := NewMimic()
+	assert.NoError(t, err)
+	defer m.Close()
+
+	assert.ErrorContains(t, m.Replay(strings.NewReader("")), "recording is empty")
+	assert.ErrorContains(t, m.Replay(strings.NewReader(`{"version": 1}`)), "unsupported asciicast version 1")
+	assert.ErrorContains(t, m.Replay(strings.NewReader("{\"version\": 2, \"width\": 80, \"height\": 24}\n[0.1, \"i\"]")), "line 2")
+}
+
+func TestMimic_Replay_resize(t *testing.T) {
+	m, err := NewMimic(WithSize(24, 80))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	cast := "{\"version\": 2, \"width\": 80, \"height\": 24}\n[0.01, \"r\", \"100x30\"]\n[0.02, \"i\", \"x\"]\n"
+	assert.NoError(t, m.Replay(strings.NewReader(cast), WithReplayTiming()))
+
+	rows, columns := m.Size()
+	assert.Equal(t, 30, rows)
+	assert.Equal(t, 100, columns)
+}
+
+func TestMimic_Replay_wideRunes(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithSize(4, 9), WithIdleTimeout(200*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	// 界 doesn't fit in the last column, so it wraps to the next row
+	output := "\x1b[38:5:2m名前\x1b[0m: 世界\r\n"
+	_, err = m.Device().Write([]byte(output))
+	assert.NoError(t, err)
+
+	event, err := json.Marshal([]interface{}{0.01, "o", output})
+	assert.NoError(t, err)
+	cast := "{\"version\": 2, \"width\": 9, \"height\": 4}\n" + string(event) + "\n"
+	assert.NoError(t, m.Replay(strings.NewReader(cast), WithReplayVerification()))
+	assert.Equal(t, "名前: 世\n界\n", m.snapshot())
+}
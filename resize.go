@@ -0,0 +1,71 @@
+package mimic
+
+import (
+	"errors"
+	"sync"
+)
+
+// ResizeHandler is notified with the new dimensions of the emulated terminal whenever they change.
+type ResizeHandler func(rows, columns int)
+
+type resizeHandlers struct {
+	mu       sync.RWMutex
+	handlers []ResizeHandler
+}
+
+func (r *resizeHandlers) add(handler ResizeHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers = append(r.handlers, handler)
+}
+
+func (r *resizeHandlers) notify(rows, columns int) {
+	r.mu.RLock()
+	handlers := make([]ResizeHandler, len(r.handlers))
+	copy(handlers, r.handlers)
+	r.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(rows, columns)
+	}
+}
+
+// OnResize registers a handler which is invoked whenever the emulated terminal's dimensions change via Mimic.Resize.
+// Handlers are invoked synchronously, in the order they were registered.
+func (m *Mimic) OnResize(handler ResizeHandler) {
+	if handler == nil {
+		return
+	}
+	m.resize.add(handler)
+}
+
+// Size returns the current dimensions of the emulated terminal
+func (m *Mimic) Size() (rows, columns int) {
+	m.terminal.Lock()
+	defer m.terminal.Unlock()
+	columns, rows = m.terminal.Size()
+	return rows, columns
+}
+
+// Resize changes the dimensions of the emulated terminal and the underlying pty, then notifies any handlers
+// registered via Mimic.OnResize. Resizing to the current dimensions is a no-op.
+func (m *Mimic) Resize(rows, columns int) error {
+	if rows < 1 || columns < 1 {
+		return errors.New("terminal dimensions must be positive")
+	}
+
+	currentRows, currentColumns := m.Size()
+	if rows == currentRows && columns == currentColumns {
+		return nil
+	}
+
+	if tty, err := m.TtyFile(); err == nil {
+		if err := setPtySize(tty, rows, columns); err != nil {
+			return err
+		}
+	}
+
+	m.terminal.Resize(columns, rows)
+	m.resize.notify(rows, columns)
+	return nil
+}
@@ -0,0 +1,28 @@
+package mimic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_OnResize(t *testing.T) {
+	m, err := NewMimic(WithSize(24, 80))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	type dimensions struct{ rows, columns int }
+	var notified []dimensions
+	m.OnResize(func(rows, columns int) {
+		notified = append(notified, dimensions{rows, columns})
+	})
+
+	assert.NoError(t, m.Resize(40, 100))
+	assert.NoError(t, m.Resize(40, 100), "resizing to the current dimensions should not fail")
+	assert.Error(t, m.Resize(0, 100), "resizing to non-positive dimensions should fail")
+
+	rows, columns := m.Size()
+	assert.Equal(t, 40, rows)
+	assert.Equal(t, 100, columns)
+	assert.Equal(t, []dimensions{{40, 100}}, notified, "handlers should be notified only when dimensions change")
+}
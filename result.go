@@ -0,0 +1,39 @@
+package mimic
+
+import "regexp"
+
+// MatchResult describes the output matched by a pattern-based expectation
+type MatchResult struct {
+	// Pattern is the pattern which matched, as provided by the caller
+	Pattern string
+	// Text is the text matched by the pattern, stripped of ANSI escape characters
+	Text string
+	// Groups holds the text of each capture group in the pattern, in order. Unmatched groups are empty.
+	Groups []string
+
+	names []string
+}
+
+// Group returns the text matched by the named capture group, or an empty string if no such group matched
+func (r MatchResult) Group(name string) string {
+	for i, n := range r.names {
+		if n == name && name != "" && i < len(r.Groups) {
+			return r.Groups[i]
+		}
+	}
+	return ""
+}
+
+func newMatchResult(pattern string, re *regexp.Regexp, contents string) MatchResult {
+	submatches := re.FindStringSubmatch(contents)
+	if submatches == nil {
+		return MatchResult{Pattern: pattern}
+	}
+
+	return MatchResult{
+		Pattern: pattern,
+		Text:    submatches[0],
+		Groups:  submatches[1:],
+		names:   re.SubexpNames()[1:],
+	}
+}
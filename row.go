@@ -0,0 +1,61 @@
+package mimic
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// WaitForRowContent waits for the zero-based row of the emulated terminal's view to match pattern, returning an error
+// if it doesn't before the idle timeout (see WithIdleTimeout and WithCallTimeout). A negative row counts from the
+// bottom of the view, so -1 is the last row, which suits status bars of TUIs whose height varies:
+//
+//	err := m.WaitForRowContent(-1, `^NORMAL .* {{semver}}$`)
+//
+// The row is stripped of ANSI escape characters and trailing whitespace before it's matched, and may have changed
+// since it matched by the time WaitForRowContent returns. Use regexp.QuoteMeta to match a plain string. An error
+// wrapping ErrInvalidPattern is returned, without waiting, if pattern is invalid.
+func (m *Mimic) WaitForRowContent(row int, pattern string) error {
+	re, err := CompilePattern(pattern)
+	if err != nil {
+		return err
+	}
+
+	matcher := &rowMatcher{m: m, row: row, re: re}
+	_, _, err = m.expect(context.Background(), matcher)
+	if err != nil {
+		text, _ := matcher.text()
+		return fmt.Errorf("row %d doesn't match %q, last seen as %q: %w", row, pattern, text, err)
+	}
+	return nil
+}
+
+// rowMatcher matches once a row of the view matches re, regardless of the content read
+type rowMatcher struct {
+	m   *Mimic
+	row int
+	re  *regexp.Regexp
+}
+
+func (r *rowMatcher) Match(_ interface{}) bool {
+	text, ok := r.text()
+	return ok && r.re.MatchString(text)
+}
+
+func (r *rowMatcher) Criteria() interface{} {
+	return r.re
+}
+
+// text is the formatted text of the row, which is false if the row is outside the view
+func (r *rowMatcher) text() (string, bool) {
+	v := Viewer{Mimic: r.m, StripAnsi: true, Trim: true}
+	lines := v.Lines()
+	row := r.row
+	if row < 0 {
+		row += len(lines)
+	}
+	if row < 0 || row >= len(lines) {
+		return "", false
+	}
+	return lines[row], true
+}
@@ -0,0 +1,31 @@
+package mimic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_WaitForRowContent(t *testing.T) {
+	m, err := NewMimic(WithSize(4, 30), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	go func() {
+		_, _ = m.Tty().WriteString("\x1b[1;1HFiles\x1b[4;1H\x1b[7mNORMAL  loading\x1b[0m")
+		time.Sleep(20 * time.Millisecond)
+		_, _ = m.Tty().WriteString("\x1b[4;1H\x1b[7mNORMAL  main.go  v1.2.0\x1b[0m\x1b[2;1H")
+	}()
+
+	assert.NoError(t, m.WaitForRowContent(-1, `^NORMAL\s+main\.go\s+{{semver}}$`))
+	assert.NoError(t, m.WaitForRowContent(0, `^Files$`))
+	assert.NoError(t, m.WaitForRowContent(3, `main\.go`), "a positive row should address the same row")
+
+	err = m.WaitForRowContent(0, `Buffers`)
+	assert.ErrorIs(t, err, ErrExpectTimeout)
+	assert.ErrorContains(t, err, `last seen as "Files"`)
+
+	assert.ErrorIs(t, m.WaitForRowContent(4, `.*`), ErrExpectTimeout, "rows outside the view never match")
+	assert.ErrorIs(t, m.WaitForRowContent(0, `(`), ErrInvalidPattern)
+}
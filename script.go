@@ -0,0 +1,125 @@
+package mimic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Script is an ordered sequence of interactions with a Mimic, built fluently and executed via Script.Run:
+//
+//	err := mimic.NewScript(m).
+//		Expect("name?").Send("Tom").
+//		Expect("age?").Timeout(5 * time.Second).Send("20").
+//		Run(ctx)
+//
+// Steps run in order, stopping at the first failure, which is reported as a *ScriptError.
+type Script struct {
+	m     *Mimic
+	steps []scriptStep
+}
+
+type scriptStep struct {
+	description string
+	timeout     time.Duration
+	run         func(ctx context.Context, m *Mimic) error
+}
+
+// ScriptError identifies the step of a Script which failed
+type ScriptError struct {
+	// Step is the one-based index of the failed step
+	Step int
+	// Description of the failed step, e.g. `Expect("name?")`
+	Description string
+	// Err is the cause of the failure
+	Err error
+}
+
+func (e *ScriptError) Error() string {
+	return fmt.Sprintf("step %d (%s): %v", e.Step, e.Description, e.Err)
+}
+
+func (e *ScriptError) Unwrap() error {
+	return e.Err
+}
+
+// NewScript creates an empty Script which interacts with m
+func NewScript(m *Mimic) *Script {
+	return &Script{m: m}
+}
+
+// Expect adds a step which waits for the output to contain one or more strings. See Mimic.ExpectString.
+func (s *Script) Expect(str ...string) *Script {
+	return s.add(FormatCall("Expect", str), func(ctx context.Context, m *Mimic) error {
+		return m.ExpectStringContext(ctx, str...)
+	})
+}
+
+// ExpectPattern adds a step which waits for the output to contain one or more patterns. See Mimic.ExpectPattern.
+func (s *Script) ExpectPattern(pattern ...string) *Script {
+	return s.add(FormatCall("ExpectPattern", pattern), func(ctx context.Context, m *Mimic) error {
+		_, err := m.ExpectPatternContext(ctx, pattern...)
+		return err
+	})
+}
+
+// Send adds a step which types text followed by enter, as when answering a prompt
+func (s *Script) Send(text string) *Script {
+	return s.add(FormatCall("Send", []string{text}), func(_ context.Context, m *Mimic) error {
+		return m.SendKey(Key(text), KeyEnter)
+	})
+}
+
+// SendKey adds a step which presses each key in order, without a trailing enter. See Mimic.SendKey.
+func (s *Script) SendKey(keys ...Key) *Script {
+	sequences := make([]string, 0, len(keys))
+	for _, key := range keys {
+		sequences = append(sequences, string(key))
+	}
+	return s.add(FormatCall("SendKey", sequences), func(_ context.Context, m *Mimic) error {
+		return m.SendKey(keys...)
+	})
+}
+
+// Timeout overrides the idle timeout (see WithIdleTimeout) of the most recently added step
+func (s *Script) Timeout(timeout time.Duration) *Script {
+	if len(s.steps) > 0 {
+		s.steps[len(s.steps)-1].timeout = timeout
+	}
+	return s
+}
+
+// Run executes each step in order, returning a *ScriptError for the first step which fails.
+// Steps are abandoned if ctx is done.
+func (s *Script) Run(ctx context.Context) error {
+	for i, step := range s.steps {
+		m := s.m
+		if step.timeout > 0 {
+			m = m.With(WithCallTimeout(step.timeout))
+		}
+
+		err := ctx.Err()
+		if err == nil {
+			err = step.run(ctx, m)
+		}
+		if err != nil {
+			return &ScriptError{Step: i + 1, Description: step.description, Err: err}
+		}
+	}
+	return nil
+}
+
+func (s *Script) add(description string, run func(ctx context.Context, m *Mimic) error) *Script {
+	s.steps = append(s.steps, scriptStep{description: description, run: run})
+	return s
+}
+
+// FormatCall formats an operation and its arguments as a call for humans, e.g. `Expect("name?")`
+func FormatCall(operation string, arguments []string) string {
+	quoted := make([]string, 0, len(arguments))
+	for _, argument := range arguments {
+		quoted = append(quoted, fmt.Sprintf("%q", argument))
+	}
+	return fmt.Sprintf("%s(%s)", operation, strings.Join(quoted, ", "))
+}
@@ -0,0 +1,62 @@
+package mimic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScript_Run(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(2 * time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	p, err := m.Spawn(context.Background(), "sh", "-c", `printf "name? "; read name; printf "age? "; read age; echo "$name is $age"`)
+	assert.NoError(t, err)
+
+	err = NewScript(m).
+		Expect("name?").Send("Tom").
+		ExpectPattern(`age\?`).Send("20").
+		Expect("Tom is 20").
+		Run(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, p.Wait())
+}
+
+func TestScript_Run_identifiesFailedStep(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(5 * time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, _ = m.Tty().WriteString("name? ")
+
+	started := time.Now()
+	err = NewScript(m).
+		Expect("name?").SendKey(KeyArrowDown).
+		Expect("age?").Timeout(50 * time.Millisecond).
+		Send("20").
+		Run(context.Background())
+
+	var scriptErr *ScriptError
+	assert.ErrorAs(t, err, &scriptErr)
+	assert.Equal(t, 3, scriptErr.Step)
+	assert.Equal(t, `Expect("age?")`, scriptErr.Description)
+	assert.ErrorContains(t, err, `step 3 (Expect("age?")): `)
+	assert.Less(t, time.Since(started), time.Second, "the step's timeout should apply")
+}
+
+func TestScript_Run_cancelled(t *testing.T) {
+	m, err := NewMimic()
+	assert.NoError(t, err)
+	defer m.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = NewScript(m).Send("hello").Run(ctx)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.ErrorContains(t, err, `step 1 (Send("hello"))`)
+}
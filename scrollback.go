@@ -0,0 +1,145 @@
+package mimic
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jimschubert/mimic/internal/vt10x"
+)
+
+// WithScrollback retains up to lines rows which scroll off the top of the emulated terminal, as a terminal emulator's
+// scrollback does. Retained rows are searched by Mimic.ContainsString and Mimic.ContainsPattern ahead of the view, and
+// are available via Mimic.Scrollback. This suits CLIs which print more than a screenful before the output a test
+// asserts on. Rows aren't retained while an application uses the alternate screen, nor when an application scrolls
+// within a scrolling region. See WithAutoGrow to instead grow the view.
+func WithScrollback(lines int) Option {
+	return func(opt *mimicOpt) {
+		opt.scrollback = lines
+	}
+}
+
+// scrollback writes to the terminal (via w), retaining the top row of the screen whenever a write scrolls it out of
+// view; a nil scrollback retains nothing. Output arrives a rune at a time, so scrolling is detected per write: by a line
+// feed on the bottom row, or by a printable rune which wraps from the last column of the bottom row.
+type scrollback struct {
+	terminal vt10x.Terminal
+	w        io.Writer
+	max      int
+
+	mu    sync.Mutex
+	lines []string
+}
+
+func (s *scrollback) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		// write up to and including each line feed separately, as each may scroll
+		end := bytes.IndexAny(p, "\n\v\f") + 1
+		if end == 0 {
+			end = len(p)
+		}
+		n, err := s.write(p[:end])
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[end:]
+	}
+	return written, nil
+}
+
+func (s *scrollback) write(p []byte) (int, error) {
+	s.terminal.Lock()
+	columns, rows := s.terminal.Size()
+	cursor := s.terminal.Cursor()
+	alternate := s.terminal.Mode()&vt10x.ModeAltScreen != 0
+	var top, next string
+	feed := bytes.ContainsAny(p, "\n\v\f")
+	wrap := !feed && cursor.X == columns-1 && isPrintable(p)
+	atBottom := cursor.Y == rows-1 && !alternate
+	if atBottom && (feed || wrap) {
+		top = rowText(s.terminal, 0, columns)
+		if wrap && rows > 1 {
+			next = rowText(s.terminal, 1, columns)
+		}
+	}
+	s.terminal.Unlock()
+
+	n, err := s.w.Write(p)
+	if !atBottom || !(feed || wrap) {
+		return n, err
+	}
+
+	s.terminal.Lock()
+	columnsAfter, rowsAfter := s.terminal.Size()
+	after := s.terminal.Cursor()
+	scrolled := rowsAfter == rows && columnsAfter == columns && after.Y == rows-1
+	if scrolled && wrap {
+		// the rune wrapped to the start of a new row only if the remaining rows moved up
+		scrolled = after.X < columns-1 && (rows == 1 || rowText(s.terminal, 0, columns) == next)
+	}
+	s.terminal.Unlock()
+
+	if scrolled {
+		s.retain(top)
+	}
+	return n, err
+}
+
+func (s *scrollback) retain(line string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lines = append(s.lines, strings.TrimRight(line, " \t"))
+	if over := len(s.lines) - s.max; over > 0 {
+		s.lines = append(s.lines[:0], s.lines[over:]...)
+	}
+}
+
+func (s *scrollback) snapshot() []string {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.lines...)
+}
+
+// rowText is the text of row y; the caller must hold the terminal's lock
+func rowText(terminal vt10x.Terminal, y, columns int) string {
+	row := make([]rune, columns)
+	for x := range row {
+		row[x] = terminal.Cell(x, y).Char
+	}
+	return withoutSpacers(string(row))
+}
+
+// isPrintable reports whether p is a single printable rune
+func isPrintable(p []byte) bool {
+	r, size := utf8.DecodeRune(p)
+	return size == len(p) && r != utf8.RuneError && unicode.IsPrint(r)
+}
+
+// Scrollback returns the rows which have scrolled off the top of the emulated terminal, oldest first, with trailing
+// whitespace removed. It returns nil unless the Mimic was created with WithScrollback.
+func (m *Mimic) Scrollback() []string {
+	if m.scrollback == nil {
+		return nil
+	}
+	if err := m.Flush(); err != nil {
+		m.debug("Scrollback failed to flush", "error", err)
+	}
+	return m.scrollback.snapshot()
+}
+
+// searchable prefixes contents of the view with rows retained by WithScrollback, if any
+func (m *Mimic) searchable(contents string) string {
+	lines := m.scrollback.snapshot()
+	if len(lines) == 0 {
+		return contents
+	}
+	return strings.Join(lines, "\n") + "\n" + contents
+}
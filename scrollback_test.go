@@ -0,0 +1,58 @@
+package mimic
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithScrollback(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithSize(3, 20), WithScrollback(4), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	for i := 1; i <= 8; i++ {
+		_, err = fmt.Fprintf(m.Device(), "line %d\r\n", i)
+		assert.NoError(t, err)
+	}
+	_, err = fmt.Fprint(m.Device(), "prompt>")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"line 3", "line 4", "line 5", "line 6"}, m.Scrollback(), "only the most recent rows should be retained")
+	assert.True(t, m.ContainsString("line 4", "line 8", "prompt>"), "scrollback should be searched along with the view")
+	assert.True(t, m.ContainsPattern(`line 6\nline 7`), "scrollback should precede the view")
+	assert.False(t, m.ContainsString("line 2"))
+}
+
+func TestWithScrollback_wrapping(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithSize(2, 10), WithScrollback(10), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = fmt.Fprint(m.Device(), strings.Repeat("a", 10)+strings.Repeat("b", 10)+strings.Repeat("c", 5))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{strings.Repeat("a", 10)}, m.Scrollback(), "a row scrolled by wrapping should be retained")
+}
+
+func TestWithScrollback_alternateScreen(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithSize(2, 10), WithScrollback(10), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = fmt.Fprint(m.Device(), "\x1b[?1049hone\r\ntwo\r\nthree\r\n\x1b[?1049l")
+	assert.NoError(t, err)
+	assert.Empty(t, m.Scrollback())
+}
+
+func TestMimic_Scrollback_disabled(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithSize(2, 10))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = fmt.Fprint(m.Device(), "one\r\ntwo\r\nthree\r\n")
+	assert.NoError(t, err)
+	assert.Nil(t, m.Scrollback())
+}
@@ -0,0 +1,87 @@
+package mimic
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// maxCSI bounds the length of a control sequence held by sgrNormalizer, matching the emulated terminal's own limit
+const maxCSI = 256
+
+// sgrNormalizer rewrites SGR (select graphic rendition) sequences which use colon-separated parameters, e.g.
+// ESC [ 38:2::135:95:175 m, into the semicolon-separated form understood by the emulated terminal, which otherwise
+// discards the parameters and resets all attributes. It wraps the terminal, holding back each control sequence until
+// it's complete.
+type sgrNormalizer struct {
+	w       io.Writer
+	pending []byte
+}
+
+func (s *sgrNormalizer) Write(p []byte) (int, error) {
+	out := make([]byte, 0, len(p))
+	for _, b := range p {
+		switch {
+		case len(s.pending) == 0:
+			if b == '\x1b' {
+				s.pending = append(s.pending, b)
+			} else {
+				out = append(out, b)
+			}
+		case len(s.pending) == 1 && b != '[':
+			out = append(out, s.pending...)
+			s.pending = s.pending[:0]
+			if b == '\x1b' {
+				s.pending = append(s.pending, b)
+			} else {
+				out = append(out, b)
+			}
+		default:
+			s.pending = append(s.pending, b)
+			if len(s.pending) > 2 && b >= 0x40 && b <= 0x7e || len(s.pending) >= maxCSI {
+				out = append(out, normalizeSGR(s.pending)...)
+				s.pending = s.pending[:0]
+			}
+		}
+	}
+	if len(out) == 0 {
+		return len(p), nil
+	}
+	if _, err := s.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// normalizeSGR returns the control sequence seq with colon-separated SGR parameters converted to semicolon-separated
+// parameters. Sub-parameters the emulated terminal doesn't support, such as underline styles (4:3) and underline colors
+// (58:...), are reduced to their main parameter or dropped.
+func normalizeSGR(seq []byte) []byte {
+	if seq[len(seq)-1] != 'm' || !bytes.ContainsRune(seq, ':') {
+		return seq
+	}
+	params := strings.Split(string(seq[2:len(seq)-1]), ";")
+	normalized := make([]string, 0, len(params))
+	for _, param := range params {
+		subs := strings.Split(param, ":")
+		switch {
+		case len(subs) == 1:
+			normalized = append(normalized, param)
+		case subs[0] == "58":
+		case subs[0] == "4" && subs[1] == "0":
+			normalized = append(normalized, "24")
+		case (subs[0] == "38" || subs[0] == "48") && subs[1] == "5" && len(subs) == 3:
+			normalized = append(normalized, subs...)
+		case (subs[0] == "38" || subs[0] == "48") && subs[1] == "2" && len(subs) >= 5:
+			// the color space identifier preceding the components is optional
+			normalized = append(normalized, subs[0], "2", subs[len(subs)-3], subs[len(subs)-2], subs[len(subs)-1])
+		case subs[0] != "38" && subs[0] != "48":
+			normalized = append(normalized, subs[0])
+		}
+	}
+	if len(normalized) == 0 {
+		// every parameter was dropped; an empty SGR sequence would reset all attributes
+		return nil
+	}
+	return []byte("\x1b[" + strings.Join(normalized, ";") + "m")
+}
@@ -0,0 +1,85 @@
+package mimic
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jimschubert/mimic/internal"
+)
+
+// UpdateSnapshotsEnv is the environment variable which, when true, causes Mimic.MatchSnapshot to rewrite golden files
+// rather than compare against them:
+//
+//	MIMIC_UPDATE_SNAPSHOTS=1 go test ./...
+const UpdateSnapshotsEnv = "MIMIC_UPDATE_SNAPSHOTS"
+
+// snapshotDir is where golden files are stored, relative to the package under test
+var snapshotDir = "testdata"
+
+// MatchSnapshot compares the terminal's current view against the golden file testdata/<name>.golden, failing t with a
+// diff if they differ or the golden file doesn't exist. When the UpdateSnapshotsEnv environment variable is true, the
+// golden file is written from the current view instead. The diff is colorized unless disabled via WithDiffColor.
+//
+// The view is recorded with ANSI escape characters stripped, trailing whitespace trimmed from each row, and trailing
+// empty rows omitted. Pending output is flushed first.
+func (m *Mimic) MatchSnapshot(t testing.TB, name string) bool {
+	t.Helper()
+
+	_ = m.Flush()
+	actual := m.snapshot()
+	path := filepath.Join(snapshotDir, filepath.FromSlash(name)+".golden")
+
+	if update, _ := strconv.ParseBool(os.Getenv(UpdateSnapshotsEnv)); update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Errorf("unable to create snapshot directory: %v", err)
+			return false
+		}
+		if err := os.WriteFile(path, []byte(actual), 0o644); err != nil {
+			t.Errorf("unable to update snapshot %s: %v", path, err)
+			return false
+		}
+		t.Logf("updated snapshot %s", path)
+		return true
+	}
+
+	expected, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Errorf("snapshot %s does not exist; run with %s=1 to create it. Current view:\n%s", path, UpdateSnapshotsEnv, actual)
+		return false
+	}
+	if err != nil {
+		t.Errorf("unable to read snapshot %s: %v", path, err)
+		return false
+	}
+
+	if string(expected) == actual {
+		return true
+	}
+
+	t.Errorf("view does not match snapshot %s; run with %s=1 to update it.\n%s", path, UpdateSnapshotsEnv, internal.UnifiedDiff(string(expected), actual, path, "Current view", m.diffColor))
+	return false
+}
+
+// snapshot formats the terminal's view for storage in a golden file
+func (m *Mimic) snapshot() string {
+	v := Viewer{Mimic: m, StripAnsi: true, Trim: true}
+	return trimView(v.Lines())
+}
+
+// trimView joins lines of a view, omitting trailing whitespace of each line and trailing empty lines
+func trimView(lines []string) string {
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,59 @@
+package mimic
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestMimic_MatchSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	original := snapshotDir
+	snapshotDir = dir
+	defer func() { snapshotDir = original }()
+
+	m, err := NewMimic(WithSize(6, 20))
+	assert.NoError(t, err)
+	defer m.Close()
+	_, _ = m.Tty().WriteString("\x1b[1mLogin\x1b[0m\r\n\r\nUser: jim  ")
+
+	t.Run("missing snapshot fails", func(t *testing.T) {
+		tb := &recordingTB{TB: t}
+		assert.False(t, m.MatchSnapshot(tb, "login/screen"))
+		assert.Len(t, tb.errors, 1)
+		assert.Contains(t, tb.errors[0], "MIMIC_UPDATE_SNAPSHOTS=1")
+	})
+
+	t.Run("update writes snapshot", func(t *testing.T) {
+		t.Setenv(UpdateSnapshotsEnv, "1")
+		assert.True(t, m.MatchSnapshot(t, "login/screen"))
+
+		golden, err := os.ReadFile(filepath.Join(dir, "login", "screen.golden"))
+		assert.NoError(t, err)
+		assert.Equal(t, "Login\n\nUser: jim\n", string(golden))
+	})
+
+	t.Run("matching snapshot passes", func(t *testing.T) {
+		assert.True(t, m.MatchSnapshot(t, "login/screen"))
+	})
+
+	t.Run("changed view fails with diff", func(t *testing.T) {
+		_, _ = m.Tty().WriteString("\r\nPass: ")
+		tb := &recordingTB{TB: t}
+		assert.False(t, m.MatchSnapshot(tb, "login/screen"))
+		assert.Len(t, tb.errors, 1)
+		assert.Contains(t, tb.errors[0], "+Pass:")
+	})
+}
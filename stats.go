@@ -0,0 +1,83 @@
+package mimic
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats are counters describing a Mimic's activity since it was created, as returned by Mimic.Stats
+type Stats struct {
+	// BytesWritten is the number of bytes of input written to the terminal, e.g. via Mimic.WriteString
+	BytesWritten int64
+	// BytesRendered is the number of bytes of output read from the terminal into the view
+	BytesRendered int64
+	// Expectations is the number of expectations attempted, e.g. via Mimic.ExpectString
+	Expectations int64
+	// Matched is the number of expectations which matched
+	Matched int64
+	// TimedOut is the number of expectations which failed with ErrExpectTimeout
+	TimedOut int64
+	// Flushes is the number of flushes of pending output into the view, including those performed by operations such
+	// as Mimic.ContainsString
+	Flushes int64
+	// WaitTime is the cumulative time spent in expectations, flushes, and Mimic.WaitForIdle
+	WaitTime time.Duration
+}
+
+// stats accumulates Stats, counting rendered bytes as they're written
+type stats struct {
+	bytesWritten  int64
+	bytesRendered int64
+	expectations  int64
+	matched       int64
+	timedOut      int64
+	flushes       int64
+	waitTime      int64
+}
+
+func (s *stats) Write(p []byte) (int, error) {
+	atomic.AddInt64(&s.bytesRendered, int64(len(p)))
+	return len(p), nil
+}
+
+func (s *stats) written(n int) {
+	atomic.AddInt64(&s.bytesWritten, int64(n))
+}
+
+func (s *stats) expected(matched, timedOut bool, began time.Time) {
+	atomic.AddInt64(&s.expectations, 1)
+	if matched {
+		atomic.AddInt64(&s.matched, 1)
+	}
+	if timedOut {
+		atomic.AddInt64(&s.timedOut, 1)
+	}
+	s.waited(began)
+}
+
+func (s *stats) flushed(began time.Time) {
+	atomic.AddInt64(&s.flushes, 1)
+	s.waited(began)
+}
+
+func (s *stats) waited(began time.Time) {
+	atomic.AddInt64(&s.waitTime, int64(time.Since(began)))
+}
+
+func (s *stats) snapshot() Stats {
+	return Stats{
+		BytesWritten:  atomic.LoadInt64(&s.bytesWritten),
+		BytesRendered: atomic.LoadInt64(&s.bytesRendered),
+		Expectations:  atomic.LoadInt64(&s.expectations),
+		Matched:       atomic.LoadInt64(&s.matched),
+		TimedOut:      atomic.LoadInt64(&s.timedOut),
+		Flushes:       atomic.LoadInt64(&s.flushes),
+		WaitTime:      time.Duration(atomic.LoadInt64(&s.waitTime)),
+	}
+}
+
+// Stats returns counters describing the Mimic's activity so far. Comparing WaitTime and TimedOut against a test's
+// runtime identifies tests which spend most of their time waiting for expectations to time out.
+func (m *Mimic) Stats() Stats {
+	return m.stats.snapshot()
+}
@@ -0,0 +1,35 @@
+package mimic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_Stats(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(50 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	assert.Equal(t, Stats{}, m.Stats())
+
+	_, err = m.WriteString("Hello")
+	assert.NoError(t, err)
+	assert.NoError(t, m.ExpectString("Hello"))
+	assert.Error(t, m.ExpectString("Goodbye"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, m.ExpectStringContext(ctx, "Goodbye"))
+	assert.NoError(t, m.Flush())
+
+	stats := m.Stats()
+	assert.Equal(t, int64(5), stats.BytesWritten)
+	assert.Equal(t, int64(5), stats.BytesRendered, "the tty echoes input into the view")
+	assert.Equal(t, int64(3), stats.Expectations)
+	assert.Equal(t, int64(1), stats.Matched)
+	assert.Equal(t, int64(1), stats.TimedOut, "cancelled expectations should not count as timed out")
+	assert.Equal(t, int64(1), stats.Flushes)
+	assert.GreaterOrEqual(t, stats.WaitTime, 50*time.Millisecond)
+}
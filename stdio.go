@@ -0,0 +1,30 @@
+package mimic
+
+import "os"
+
+// Stdio holds the standard streams for a program interacting with the emulated terminal. See Mimic.Stdio.
+type Stdio struct {
+	In  *os.File
+	Out *os.File
+	Err *os.File
+}
+
+// Stdio returns the emulated terminal's tty as each of a program's standard streams, or ErrNoPty if the terminal is
+// emulated in memory (see WithInMemoryPty); use Mimic.Device instead.
+func (m *Mimic) Stdio() (Stdio, error) {
+	tty, err := m.TtyFile()
+	if err != nil {
+		return Stdio{}, err
+	}
+	return Stdio{In: tty, Out: tty, Err: tty}, nil
+}
+
+// Files returns the standard input, output, and error streams, in that order. This allows passing the streams directly
+// to functions which accept all three, such as survey.WithStdio from github.com/AlecAivazis/survey/v2:
+//
+//	stdio, err := console.Stdio()
+//	// …
+//	survey.AskOne(prompt, &answer, survey.WithStdio(stdio.Files()))
+func (s Stdio) Files() (in, out, err *os.File) {
+	return s.In, s.Out, s.Err
+}
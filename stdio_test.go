@@ -0,0 +1,32 @@
+package mimic
+
+import (
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// withStdio mimics the signature of survey.WithStdio
+func withStdio(in fileReader, out fileWriter, err io.Writer) []interface{} {
+	return []interface{}{in, out, err}
+}
+
+func TestMimic_Stdio(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(50 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	stdio, err := m.Stdio()
+	assert.NoError(t, err)
+	assert.Same(t, m.Tty(), stdio.In)
+	assert.Same(t, m.Tty(), stdio.Out)
+	assert.Same(t, m.Tty(), stdio.Err)
+	assert.Len(t, withStdio(stdio.Files()), 3)
+
+	_, err = fmt.Fprint(stdio.Err, "Hello")
+	assert.NoError(t, err)
+	assert.NoError(t, m.ExpectString("Hello"))
+}
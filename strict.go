@@ -0,0 +1,199 @@
+package mimic
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/jimschubert/mimic/internal"
+	"github.com/jimschubert/mimic/internal/expect"
+	"github.com/jimschubert/stripansi"
+)
+
+// WithStrict causes Mimic.NoMoreExpectations to fail if any line of output was never matched. A line is matched when
+// an expectation (e.g. Mimic.ExpectString) matches text on it, or a successful Contains check (e.g.
+// Mimic.ContainsString) finds its criteria on it. Blank lines, and lines which only echo input sent to the terminal,
+// are exempt. This catches applications which print output no test asserted on, such as deprecation warnings.
+func WithStrict() Option {
+	return func(opt *mimicOpt) {
+		opt.strict = true
+	}
+}
+
+// strictLine is a line of output, and whether it has been matched
+type strictLine struct {
+	text     string
+	consumed bool
+}
+
+// strictTracker accounts for which lines of output have been matched; a nil strictTracker tracks nothing
+type strictTracker struct {
+	mu     sync.Mutex
+	lines  []strictLine
+	inputs map[string]struct{}
+}
+
+// read appends output read from the pty. The first line of output continues the current line.
+func (s *strictTracker) read(output string) {
+	if s == nil || output == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, text := range strings.Split(strings.ReplaceAll(stripansi.String(output), "\r", ""), "\n") {
+		if i == 0 && len(s.lines) > 0 {
+			s.lines[len(s.lines)-1].text += text
+			continue
+		}
+		s.lines = append(s.lines, strictLine{text: text})
+	}
+}
+
+// consumeLast marks the last n lines as matched
+func (s *strictTracker) consumeLast(n int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(s.lines) - 1; i >= 0 && i >= len(s.lines)-n; i-- {
+		s.lines[i].consumed = true
+	}
+	s.prune()
+}
+
+// consumeMatching marks lines for which match is true as matched
+func (s *strictTracker) consumeMatching(match func(line string) bool) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.lines {
+		if !s.lines[i].consumed && match(s.lines[i].text) {
+			s.lines[i].consumed = true
+		}
+	}
+	s.prune()
+}
+
+// prune discards matched lines, other than the current line which subsequent output continues
+func (s *strictTracker) prune() {
+	retained := s.lines[:0]
+	for i, line := range s.lines {
+		if !line.consumed || i == len(s.lines)-1 {
+			retained = append(retained, line)
+		}
+	}
+	s.lines = retained
+}
+
+// sent records input sent to the terminal, so that its echo isn't considered unexpected
+func (s *strictTracker) sent(input string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.inputs == nil {
+		s.inputs = make(map[string]struct{})
+	}
+	for _, line := range strings.FieldsFunc(input, func(r rune) bool { return r == '\r' || r == '\n' }) {
+		if line = strings.TrimSpace(line); line != "" {
+			s.inputs[line] = struct{}{}
+		}
+	}
+}
+
+// unexpected returns an error listing the lines of output which were never matched
+func (s *strictTracker) unexpected() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var unmatched []string
+	for _, line := range s.lines {
+		text := strings.TrimSpace(line.text)
+		if _, echoed := s.inputs[text]; line.consumed || text == "" || echoed {
+			continue
+		}
+		unmatched = append(unmatched, "  "+strings.TrimRight(line.text, " \t"))
+	}
+	if len(unmatched) == 0 {
+		return nil
+	}
+	return fmt.Errorf("strict mode: %d line(s) of output were not matched by any expectation:\n%s", len(unmatched), strings.Join(unmatched, "\n"))
+}
+
+// consumeMatch marks the lines of output spanned by the text which matcher matched as consumed. Expectations stop
+// reading as soon as a matcher matches, so the match always extends to the end of output.
+func (s *strictTracker) consumeMatch(output string, matcher expect.Matcher) {
+	if s == nil {
+		return
+	}
+
+	stripped := strings.ReplaceAll(stripansi.String(output), "\r", "")
+	start := -1
+	switch v := matcher.(type) {
+	case *internal.PlainStringMatcher:
+		start = strings.LastIndex(stripped, v.S)
+	case *internal.RegexpMatcher:
+		if loc := v.Re.FindStringIndex(stripped); loc != nil {
+			start = loc[0]
+		}
+	case *streamMatcher:
+		switch p := v.matcher.(type) {
+		case textMatcher:
+			start = strings.LastIndex(stripped, p.s)
+		case regexpMatcher:
+			if loc := p.re.FindStringIndex(stripped); loc != nil {
+				start = loc[0]
+			}
+		}
+	default:
+		return
+	}
+
+	if start < 0 {
+		// e.g. locale-aware comparisons, where the matched text may differ from the criteria, or custom matchers
+		s.consumeLast(1)
+		return
+	}
+	s.consumeLast(1 + strings.Count(stripped[start:], "\n"))
+}
+
+// consumeContains marks lines which contain any of the criteria as consumed, following a successful Contains check
+func (s *strictTracker) consumeContains(matchers []expect.Matcher) {
+	s.consumeMatching(func(line string) bool {
+		for _, matcher := range matchers {
+			switch v := matcher.(type) {
+			case *internal.PlainStringMatcher:
+				if v.Match(bytes.NewBufferString(line)) {
+					return true
+				}
+			case *internal.RegexpMatcher:
+				if v.Re.MatchString(line) {
+					return true
+				}
+			}
+		}
+		return false
+	})
+}
+
+// regexpMatchers wraps patterns as matchers
+func regexpMatchers(res []*regexp.Regexp) []expect.Matcher {
+	matchers := make([]expect.Matcher, 0, len(res))
+	for _, re := range res {
+		matchers = append(matchers, &internal.RegexpMatcher{Re: re})
+	}
+	return matchers
+}
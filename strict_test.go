@@ -0,0 +1,109 @@
+package mimic
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithStrict(t *testing.T) {
+	script := `echo "Warning: --legacy is deprecated"; printf "name? "; read name; echo "hello $name"; echo "Saved to /tmp/out"; echo; echo "bye"`
+	tests := []struct {
+		name      string
+		strict    bool
+		contains  []string
+		wantLines []string
+	}{
+		{name: "unmatched lines are reported", strict: true, wantLines: []string{"Warning: --legacy is deprecated", "Saved to /tmp/out", "bye"}},
+		{name: "contains checks match lines", strict: true, contains: []string{"deprecated", "Saved", "bye"}},
+		{name: "disabled", contains: []string{"bye"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []Option{WithIdleTimeout(time.Second)}
+			if tt.strict {
+				opts = append(opts, WithStrict())
+			}
+			m, err := NewMimic(opts...)
+			assert.NoError(t, err)
+			defer m.Close()
+
+			p, err := m.Spawn(context.Background(), "sh", "-c", script)
+			assert.NoError(t, err)
+			assert.NoError(t, m.ExpectString("name?"))
+			_, err = m.WriteString("Tom\r")
+			assert.NoError(t, err)
+			assert.NoError(t, m.ExpectString("hello Tom"))
+			assert.NoError(t, p.Wait())
+
+			for _, s := range tt.contains {
+				assert.True(t, m.ContainsString(s))
+			}
+
+			err = m.NoMoreExpectations()
+			if len(tt.wantLines) == 0 {
+				assert.NotContains(t, errorString(err), "strict mode")
+				return
+			}
+			assert.ErrorContains(t, err, "strict mode: 3 line(s) of output were not matched")
+			for _, line := range tt.wantLines {
+				assert.ErrorContains(t, err, "\n  "+line)
+			}
+			assert.NotContains(t, err.Error(), "hello Tom")
+			assert.NotContains(t, err.Error(), "  Tom\n", "echoed input should be exempt")
+		})
+	}
+}
+
+func TestStrictTracker_consumeMatch(t *testing.T) {
+	s := &strictTracker{}
+	s.read("first\r\nsecond\r\nthird")
+	s.consumeMatch("first\r\nsecond\r\nthird", &viewMatcher{})
+	s.consumeMatch("first\r\nsecond\r\nthird", regexpMatchers([]*regexp.Regexp{regexp.MustCompile(`second\s+thi`)})[0])
+	assert.ErrorContains(t, s.unexpected(), "1 line(s)")
+	assert.ErrorContains(t, s.unexpected(), "\n  first")
+}
+
+func TestWithStrict_matchers(t *testing.T) {
+	tests := []struct {
+		name    string
+		matcher Matcher
+	}{
+		{name: "Text", matcher: Text("hello world")},
+		{name: "Regexp", matcher: Regexp(regexp.MustCompile(`hello\s+world`))},
+		{name: "custom", matcher: MatchFunc("greeting", func(view StreamView) bool { return strings.Contains(view.Output, "world") })},
+		{name: "combined", matcher: All(Text("hello"), Not(Text("goodbye")))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMimic(WithStrict())
+			assert.NoError(t, err)
+			defer m.Close()
+
+			_, err = m.Tty().WriteString("hello world")
+			assert.NoError(t, err)
+			_, err = m.ExpectMatcher(tt.matcher)
+			assert.NoError(t, err)
+			assert.NoError(t, m.strict.unexpected())
+		})
+	}
+}
+
+func TestWithStrict_failedWrite(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithStrict(), WithFaultInjection(FaultInjection{Write: FaultLimit(3)}))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.WriteString("secret\r")
+	assert.ErrorIs(t, err, io.ErrShortWrite)
+	assert.Empty(t, m.strict.inputs, "input of a failed write shouldn't be exempt")
+
+	_, err = m.WriteString("ok\r")
+	assert.NoError(t, err)
+	assert.Contains(t, m.strict.inputs, "ok")
+}
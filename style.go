@@ -0,0 +1,124 @@
+package mimic
+
+// StyleOption describes how text must be rendered to satisfy Mimic.ContainsStyledString
+type StyleOption func(*style)
+
+type style struct {
+	foreground *Color
+	background *Color
+	bold       bool
+	underline  bool
+	reverse    bool
+	italic     bool
+	blink      bool
+}
+
+// WithForeground requires text to be rendered with the foreground color c. As terminals render bold text in the first
+// 8 ANSI colors with the bright variant, c also matches its bright variant for bold text (e.g. Red matches bold LightRed).
+func WithForeground(c Color) StyleOption {
+	return func(s *style) {
+		s.foreground = &c
+	}
+}
+
+// WithBackground requires text to be rendered with the background color c
+func WithBackground(c Color) StyleOption {
+	return func(s *style) {
+		s.background = &c
+	}
+}
+
+// WithBold requires text to be bold
+func WithBold() StyleOption {
+	return func(s *style) {
+		s.bold = true
+	}
+}
+
+// WithUnderline requires text to be underlined
+func WithUnderline() StyleOption {
+	return func(s *style) {
+		s.underline = true
+	}
+}
+
+// WithReverse requires text to be rendered in reverse video. Note that colors of reversed text are swapped, so
+// WithForeground refers to the color the text is displayed in.
+func WithReverse() StyleOption {
+	return func(s *style) {
+		s.reverse = true
+	}
+}
+
+// WithItalic requires text to be italic
+func WithItalic() StyleOption {
+	return func(s *style) {
+		s.italic = true
+	}
+}
+
+// WithBlink requires text to blink
+func WithBlink() StyleOption {
+	return func(s *style) {
+		s.blink = true
+	}
+}
+
+// ContainsStyledString determines if the emulated terminal's view contains str on a single row, with every character
+// rendered according to opts. Attributes not specified by opts are ignored.
+//
+//	m.ContainsStyledString("ERROR", mimic.WithForeground(mimic.Red), mimic.WithBold())
+func (m *Mimic) ContainsStyledString(str string, opts ...StyleOption) bool {
+	s := &style{}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	want := []rune(str)
+	for _, row := range m.Screen() {
+		row = withoutContinuations(row)
+		for start := 0; start+len(want) <= len(row); start++ {
+			if s.matches(want, row[start:start+len(want)]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// withoutContinuations omits the cells covered by the right half of wide runes, leaving a cell per rune
+func withoutContinuations(row []Cell) []Cell {
+	cells := make([]Cell, 0, len(row))
+	for _, cell := range row {
+		if cell.Width > 0 {
+			cells = append(cells, cell)
+		}
+	}
+	return cells
+}
+
+func (s *style) matches(want []rune, cells []Cell) bool {
+	for i, cell := range cells {
+		if cell.Rune != want[i] || !s.matchesCell(cell) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *style) matchesCell(cell Cell) bool {
+	if s.foreground != nil && cell.Foreground != *s.foreground {
+		brightened := cell.Bold && *s.foreground < DarkGrey && cell.Foreground == *s.foreground+8
+		if !brightened {
+			return false
+		}
+	}
+	if s.background != nil && cell.Background != *s.background {
+		return false
+	}
+	return (!s.bold || cell.Bold) &&
+		(!s.underline || cell.Underline) &&
+		(!s.reverse || cell.Reverse) &&
+		(!s.italic || cell.Italic) &&
+		(!s.blink || cell.Blink)
+}
@@ -0,0 +1,38 @@
+package mimic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_ContainsStyledString(t *testing.T) {
+	m, err := NewMimic()
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, _ = m.Tty().WriteString("\x1b[1;31mERROR\x1b[0m: failed\r\n\x1b[33mWARN\x1b[0m \x1b[4;44mnote\x1b[0m")
+
+	tests := []struct {
+		name string
+		str  string
+		opts []StyleOption
+		want bool
+	}{
+		{name: "unstyled criteria", str: "ERROR", want: true},
+		{name: "bold red", str: "ERROR", opts: []StyleOption{WithForeground(Red), WithBold()}, want: true},
+		{name: "bright variant", str: "ERROR", opts: []StyleOption{WithForeground(LightRed)}, want: true},
+		{name: "wrong color", str: "ERROR", opts: []StyleOption{WithForeground(Green)}},
+		{name: "partially styled", str: "ERROR:", opts: []StyleOption{WithForeground(Red)}},
+		{name: "not bold", str: "WARN", opts: []StyleOption{WithForeground(Yellow), WithBold()}},
+		{name: "non-bold yellow", str: "WARN", opts: []StyleOption{WithForeground(Yellow)}, want: true},
+		{name: "background and underline", str: "note", opts: []StyleOption{WithBackground(Blue), WithUnderline()}, want: true},
+		{name: "default colors", str: "failed", opts: []StyleOption{WithForeground(DefaultColor), WithBackground(DefaultColor)}, want: true},
+		{name: "absent", str: "PANIC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, m.ContainsStyledString(tt.str, tt.opts...))
+		})
+	}
+}
@@ -1,12 +1,16 @@
 package suite
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"runtime"
 	"runtime/debug"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -53,19 +57,66 @@ func WithMaxRuntime(duration time.Duration) SuiteOption {
 	}
 }
 
+// WithArtifactsDir writes artifacts describing each failing test to dir, which is created if necessary. For a test
+// whose mimic was constructed via Suite.Mimic, the following files are written, named after the suite and test:
+//
+//   - <Suite>_<Test>.view.txt: the terminal view at the end of the test
+//   - <Suite>_<Test>.transcript.txt: the test's interactions with the mimic (see mimic.WithTranscript)
+//   - <Suite>_<Test>.cast: an asciicast recording of the terminal (see mimic.WithRecording)
+//
+// This allows inspecting failures from CI without re-running tests locally.
+func WithArtifactsDir(dir string) SuiteOption {
+	return func(b *Suite) {
+		b.artifactsDir = dir
+	}
+}
+
+// WithMimicPerTest constructs a mimic for each test in BeforeTest, using opts, which is available to the test via
+// Suite.CurrentMimic. The test fails immediately if the mimic can't be constructed. Tests may still invoke Suite.Mimic,
+// which returns the same mimic. Ignored when a suite-level mimic is set via Suite.SetSuiteMimic.
+func WithMimicPerTest(opts ...mimic.Option) SuiteOption {
+	return func(b *Suite) {
+		b.perTest = true
+		b.perTestOptions = opts
+	}
+}
+
+// WithBeforeEach registers fn to be invoked before each test in the suite, after the test's mimic (if any, see
+// WithMimicPerTest) has been constructed. This allows common priming, such as spawning a shell or logging in, to be
+// declared once for the suite. Hooks are invoked in the order they're registered.
+func WithBeforeEach(fn func(tc *TestCase)) SuiteOption {
+	return func(b *Suite) {
+		b.beforeEach = append(b.beforeEach, fn)
+	}
+}
+
+// WithAfterEach registers fn to be invoked after each test in the suite, before the test's mimic is closed.
+// Hooks are invoked in the order they're registered.
+func WithAfterEach(fn func(tc *TestCase)) SuiteOption {
+	return func(b *Suite) {
+		b.afterEach = append(b.afterEach, fn)
+	}
+}
+
 type Suite struct {
+	mu         sync.Mutex
 	t          *testing.T
-	testCases  map[string]*testCase
+	testCases  sync.Map // map[string]*TestCase, keyed by Suite.key
 	suiteMimic *mimic.Mimic
 	maxRuntime time.Duration
 
+	artifactsDir   string
+	perTest        bool
+	perTestOptions []mimic.Option
+	beforeEach     []func(tc *TestCase)
+	afterEach      []func(tc *TestCase)
+
 	ctx  context.Context
 	quit chan struct{}
 	once sync.Once
 }
 
 func (b *Suite) initialize() {
-	b.testCases = make(map[string]*testCase)
 	b.ctx = context.Background()
 	b.quit = make(chan struct{})
 }
@@ -80,20 +131,38 @@ func (b *Suite) SuiteOptions() []SuiteOption {
 	}
 }
 
-// T obtains a reference to the underlying testing.T used by the suite
+// T obtains a reference to the underlying testing.T used by the suite.
+// When invoked directly from a test method, T returns the testing.T of that test, even if other tests in the suite have
+// since started (e.g. after calling T().Parallel()). Otherwise, T returns the testing.T most recently set via SetT.
 func (b *Suite) T() *testing.T {
+	if tc := b.testCase(b.caller()); tc != nil && tc.t != nil {
+		return tc.t
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
 	return b.t
 }
 
+// Context returns the suite's context, which is done once the suite's max runtime (see WithMaxRuntime) is exceeded or
+// the suite is torn down. Pass it to operations such as mimic.Mimic.WaitForIdle and mimic.Mimic.Spawn so that they're
+// abandoned along with the suite.
+func (b *Suite) Context() context.Context {
+	b.once.Do(b.initialize)
+	return b.ctx
+}
+
 // SetT is intended for use internally for setting the generated testing.T for a given test
 func (b *Suite) SetT(t *testing.T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
 	b.t = t
 }
 
 // SetSuiteMimic allows for a suite-level mimic reference.
 // This can be helpful for complex suites applying test cases across a global pty. However, such tests can
 // be flaky. suite.Suite is built upon testify's Suite which guarantees serial invocation, which helps.
-// Use this sparingly.
+// Use this sparingly, and not at all with tests which call T().Parallel(): every test shares the suite mimic's terminal.
 func (b *Suite) SetSuiteMimic(m *mimic.Mimic) {
 	b.suiteMimic = m
 }
@@ -102,29 +171,91 @@ func (b *Suite) key(suiteName string, testName string) string {
 	return fmt.Sprintf("%s_%s", suiteName, testName)
 }
 
-// BeforeTest applies test-level preparations prior to running a test found within the suite
+// BeforeTest applies test-level preparations prior to running a test found within the suite.
+// The testing.T set via SetT at this point is associated with the test, so that T resolves to it for the test's
+// duration.
 func (b *Suite) BeforeTest(suiteName string, testName string) {
-	key := b.key(suiteName, testName)
-	b.testCases[key] = &testCase{
+	b.mu.Lock()
+	t := b.t
+	b.mu.Unlock()
+
+	tc := &TestCase{
 		TestName: testName,
+		suite:    b,
+		t:        t,
 		mimic:    b.suiteMimic,
 	}
+	b.testCases.Store(b.key(suiteName, testName), tc)
+
+	if b.perTest && tc.mimic == nil {
+		if _, err := b.newMimic(tc, b.perTestOptions); err != nil {
+			t.Fatalf("unable to construct mimic for %s: %v", testName, err)
+		}
+	}
+
+	for _, fn := range b.beforeEach {
+		fn(tc)
+	}
 }
 
 // AfterTest applies test-level cleanup after running a test found within the suite
 func (b *Suite) AfterTest(suiteName string, testName string) {
-	key := b.key(suiteName, testName)
-	v := b.testCases[key]
-	if v == nil {
+	v, ok := b.testCases.LoadAndDelete(b.key(suiteName, testName))
+	if !ok {
 		return
 	}
 
+	tc := v.(*TestCase)
+	for _, fn := range b.afterEach {
+		fn(tc)
+	}
+
 	// todo: reset suite mimic's console after each test?
-	if b.suiteMimic == nil && v.mimic != nil {
-		_ = v.mimic.Close()
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if tc.mimic == nil || tc.mimic == b.suiteMimic {
+		return
+	}
+
+	var view, transcript string
+	failed := b.artifactsDir != "" && tc.t != nil && tc.t.Failed()
+	if failed {
+		_ = tc.mimic.Flush()
+		v := mimic.Viewer{Mimic: tc.mimic, StripAnsi: true, Trim: true}
+		view, transcript = v.String(), tc.mimic.Transcript().String()
+	}
+
+	_ = tc.mimic.Close()
+
+	if failed {
+		if err := b.writeArtifacts(b.key(suiteName, testName), view, transcript, tc.recording); err != nil {
+			tc.t.Logf("unable to write test artifacts: %v", err)
+		}
 	}
 }
 
+// writeArtifacts writes the artifacts of a failed test to the artifacts directory, using key as the file name prefix
+func (b *Suite) writeArtifacts(key, view, transcript string, recording *bytes.Buffer) error {
+	if err := os.MkdirAll(b.artifactsDir, 0o755); err != nil {
+		return err
+	}
+
+	artifacts := map[string][]byte{
+		key + ".view.txt":       []byte(view),
+		key + ".transcript.txt": []byte(transcript),
+	}
+	if recording != nil && recording.Len() > 0 {
+		artifacts[key+".cast"] = recording.Bytes()
+	}
+
+	for name, contents := range artifacts {
+		if err := os.WriteFile(filepath.Join(b.artifactsDir, name), contents, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SetupTestSuite applies suite-level setup logic
 func (b *Suite) SetupTestSuite() {
 	options := b.SuiteOptions()
@@ -148,42 +279,80 @@ func (b *Suite) TearDownSuite() {
 	}
 }
 
+// caller returns the key of the suite test method on the calling stack, or an empty string if the caller isn't running
+// within a suite test. The stack is walked rather than inspecting a fixed frame, so helpers invoked by a test method
+// resolve to that test, and concurrently running tests resolve to their own keys.
 func (b *Suite) caller() string {
-	counter, _, _, success := runtime.Caller(2)
-	if !success {
-		return ""
-	}
-
-	// e.g. github.com/jimschubert/mimic.(*MyTests).TestSomethingElse
-	invoker := runtime.FuncForPC(counter).Name()
-	var suiteName, testName string
-	for _, match := range suiteTestPattern.FindAllStringSubmatch(invoker, -1) {
-		for groupIdx, group := range match {
-			if groupIdx == 1 {
-				suiteName = group
-			} else if groupIdx == 2 {
-				testName = group
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+
+		// e.g. github.com/jimschubert/mimic.(*MyTests).TestSomethingElse
+		for _, match := range suiteTestPattern.FindAllStringSubmatch(frame.Function, -1) {
+			suiteName, testName := match[1], match[2]
+			if strings.HasPrefix(testName, "Test") {
+				return b.key(suiteName, testName)
 			}
 		}
-	}
 
-	return b.key(suiteName, testName)
+		if !more {
+			return ""
+		}
+	}
 }
 
 // Mimic constructs a new mimic for the given opts, which is specific to the current test case.
+// Subsequent invocations from the same test return the same mimic.
 func (b *Suite) Mimic(opts ...mimic.Option) (*mimic.Mimic, error) {
 	key := b.caller()
 	if key == "" {
 		return nil, errors.New("unable to determine name of calling test function")
 	}
 
-	if tc, ok := b.testCases[key]; ok && tc.mimic != nil {
+	v, _ := b.testCases.LoadOrStore(key, &TestCase{suite: b, mimic: b.suiteMimic})
+	return b.newMimic(v.(*TestCase), opts)
+}
+
+// CurrentMimic returns the mimic of the calling test: the mimic constructed for the test by WithMimicPerTest or
+// Suite.Mimic, or the suite-level mimic. Returns nil if the test has no mimic.
+func (b *Suite) CurrentMimic() *mimic.Mimic {
+	tc := b.testCase(b.caller())
+	if tc == nil {
+		return b.suiteMimic
+	}
+
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	return tc.mimic
+}
+
+// newMimic constructs the mimic of tc for opts, unless tc already has one
+func (b *Suite) newMimic(tc *TestCase, opts []mimic.Option) (*mimic.Mimic, error) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if tc.mimic != nil {
 		return tc.mimic, nil
 	}
 
+	if b.artifactsDir != "" {
+		// prepended, so that a transcript or recording requested by the test takes precedence
+		tc.recording = new(bytes.Buffer)
+		opts = append([]mimic.Option{mimic.WithTranscript(), mimic.WithRecording(tc.recording)}, opts...)
+	}
+
 	var err error
-	b.testCases[key].mimic, err = mimic.NewMimic(opts...)
-	return b.testCases[key].mimic, err
+	tc.mimic, err = mimic.NewMimic(opts...)
+	return tc.mimic, err
+}
+
+// testCase returns the tracked test case for key, or nil if the test isn't running
+func (b *Suite) testCase(key string) *TestCase {
+	if v, ok := b.testCases.Load(key); ok {
+		return v.(*TestCase)
+	}
+	return nil
 }
 
 // Init applies suite options to initialize the test suite
@@ -194,7 +363,25 @@ func (b *Suite) Init(opts ...SuiteOption) {
 	}
 }
 
-type testCase struct {
+// TestCase is a test running within a Suite, as provided to the hooks of WithBeforeEach and WithAfterEach
+type TestCase struct {
+	// TestName is the name of the test method, e.g. TestLogin
 	TestName string
-	mimic    *mimic.Mimic
+
+	suite *Suite
+	t     *testing.T
+
+	mu        sync.Mutex
+	mimic     *mimic.Mimic
+	recording *bytes.Buffer
+}
+
+// T returns the testing.T of the test
+func (tc *TestCase) T() *testing.T {
+	return tc.t
+}
+
+// Mimic returns the mimic of the test, constructing it for opts if the test doesn't have one. See Suite.Mimic.
+func (tc *TestCase) Mimic(opts ...mimic.Option) (*mimic.Mimic, error) {
+	return tc.suite.newMimic(tc, opts)
 }
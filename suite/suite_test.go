@@ -1,8 +1,11 @@
 package suite
 
 import (
+	"bytes"
 	"context"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -108,6 +111,19 @@ func (m *MyTests) TestMimicWaitingForIdle() {
 	assert.Error(m.T(), console.ExpectString(strings.Repeat(".", targetCount+2)), "Console did not include expected contents… Was: empty")
 }
 
+func (m *MyTests) TestContext() {
+	deadline, ok := m.Context().Deadline()
+	assert.True(m.T(), ok, "the suite's context should be bound to its max runtime")
+	assert.WithinDuration(m.T(), time.Now().Add(m.suiteRuntimeDuration), deadline, m.suiteRuntimeDuration)
+
+	console, err := m.Mimic(mimic.WithIdleDuration(10*time.Millisecond), mimic.WithIdleStrategy(mimic.ContentStable))
+	assert.NoError(m.T(), err)
+	_, err = console.Tty().WriteString("ready")
+	assert.NoError(m.T(), err)
+	assert.NoError(m.T(), console.WaitForIdle(m.Context()))
+	assert.True(m.T(), console.ContainsString("ready"))
+}
+
 func TestMimicOperationsSuite(t *testing.T) {
 	test := new(MyTests)
 	test.suiteRuntimeDuration = 30 * time.Second
@@ -115,3 +131,154 @@ func TestMimicOperationsSuite(t *testing.T) {
 
 	suite.Run(t, test)
 }
+
+type ParallelTests struct {
+	Suite
+}
+
+func (p *ParallelTests) assertIsolated(name string) {
+	t := p.T()
+	t.Parallel()
+
+	console, err := p.Mimic(mimic.WithIdleTimeout(time.Second))
+	assert.NoError(t, err)
+	same, _ := p.Mimic()
+	assert.Same(t, console, same, "a test should receive the same mimic on each invocation")
+
+	_, err = console.WriteString(name)
+	assert.NoError(t, err)
+	assert.NoError(t, console.ExpectString(name))
+
+	// give the other parallel tests an opportunity to write, then verify none of their output reached this terminal
+	time.Sleep(50 * time.Millisecond)
+	assert.NoError(t, console.Flush())
+	assert.Equal(t, name, (&mimic.Viewer{Mimic: console, StripAnsi: true, Trim: true}).Line(0))
+	assert.Equal(t, "TestParallel"+strings.TrimPrefix(name, "parallel-"), strings.TrimPrefix(p.T().Name(), "TestParallelSuite/"))
+}
+
+func (p *ParallelTests) TestParallelOne() {
+	p.assertIsolated("parallel-One")
+}
+
+func (p *ParallelTests) TestParallelTwo() {
+	p.assertIsolated("parallel-Two")
+}
+
+func (p *ParallelTests) TestParallelThree() {
+	p.assertIsolated("parallel-Three")
+}
+
+func TestParallelSuite(t *testing.T) {
+	suite.Run(t, new(ParallelTests))
+}
+
+func TestSuite_writeArtifacts(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "artifacts")
+	b := new(Suite)
+	b.Init(WithArtifactsDir(dir))
+
+	recording := bytes.NewBufferString(`{"version": 2}`)
+	assert.NoError(t, b.writeArtifacts("MyTests_TestFailure", "Hello", "1. +0.000s ExpectString(\"World\")\n", recording))
+
+	view, err := os.ReadFile(filepath.Join(dir, "MyTests_TestFailure.view.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", string(view))
+
+	transcript, err := os.ReadFile(filepath.Join(dir, "MyTests_TestFailure.transcript.txt"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(transcript), `ExpectString("World")`)
+
+	cast, err := os.ReadFile(filepath.Join(dir, "MyTests_TestFailure.cast"))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"version": 2}`, string(cast))
+}
+
+type ArtifactTests struct {
+	Suite
+}
+
+func (a *ArtifactTests) TestPassingTestWritesNoArtifacts() {
+	console, err := a.Mimic()
+	assert.NoError(a.T(), err)
+	_, err = console.WriteString("Hello")
+	assert.NoError(a.T(), err)
+	assert.NoError(a.T(), console.ExpectString("Hello"))
+	assert.Len(a.T(), console.Transcript(), 2, "artifacts should enable the transcript")
+}
+
+func TestArtifactsSuite(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "artifacts")
+	test := new(ArtifactTests)
+	test.Init(WithArtifactsDir(dir))
+	suite.Run(t, test)
+
+	_, err := os.Stat(dir)
+	assert.True(t, os.IsNotExist(err), "artifacts should only be written for failing tests")
+}
+
+type PerTestTests struct {
+	Suite
+	previous *mimic.Mimic
+}
+
+func (p *PerTestTests) TestCurrentMimicIsConstructed() {
+	console := p.CurrentMimic()
+	if assert.NotNil(p.T(), console) {
+		rows, columns := console.Size()
+		assert.Equal(p.T(), 5, rows)
+		assert.Equal(p.T(), 20, columns)
+
+		same, err := p.Mimic()
+		assert.NoError(p.T(), err)
+		assert.Same(p.T(), console, same)
+	}
+	p.previous = console
+}
+
+func (p *PerTestTests) TestCurrentMimicIsFreshPerTest() {
+	console := p.CurrentMimic()
+	assert.NotNil(p.T(), console)
+	assert.NotSame(p.T(), p.previous, console)
+}
+
+func TestPerTestSuite(t *testing.T) {
+	test := new(PerTestTests)
+	test.Init(WithMimicPerTest(mimic.WithSize(5, 20)))
+	suite.Run(t, test)
+}
+
+type HookTests struct {
+	Suite
+	events []string
+}
+
+func (h *HookTests) TestPrimedByHook() {
+	h.events = append(h.events, "test")
+	console := h.CurrentMimic()
+	if assert.NotNil(h.T(), console) {
+		assert.True(h.T(), console.ContainsString("primed for TestPrimedByHook"))
+	}
+}
+
+func TestHookSuite(t *testing.T) {
+	test := new(HookTests)
+	test.Init(
+		WithBeforeEach(func(tc *TestCase) {
+			console, err := tc.Mimic(mimic.WithSize(5, 40))
+			assert.NoError(tc.T(), err)
+			_, err = console.Tty().WriteString("primed for " + tc.TestName)
+			assert.NoError(tc.T(), err)
+			test.events = append(test.events, "before")
+		}),
+		WithAfterEach(func(tc *TestCase) {
+			console, err := tc.Mimic()
+			assert.NoError(tc.T(), err)
+			_, err = console.WriteString("still open")
+			assert.NoError(tc.T(), err, "hooks should run before the mimic is closed")
+			test.events = append(test.events, "after")
+		}),
+	)
+	suite.Run(t, test)
+
+	assert.Equal(t, []string{"before", "test", "after"}, test.events)
+}
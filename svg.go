@@ -0,0 +1,99 @@
+package mimic
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SVGOption configures RenderSVG
+type SVGOption func(*svgOptions)
+
+type svgOptions struct {
+	fontSize   float64
+	fontFamily string
+}
+
+// WithSVGFontSize sets the font size, in pixels, used by RenderSVG. Cell dimensions are derived from the font size.
+// Defaults to 14.
+func WithSVGFontSize(size float64) SVGOption {
+	return func(o *svgOptions) {
+		if size > 0 {
+			o.fontSize = size
+		}
+	}
+}
+
+// WithSVGFontFamily sets the CSS font family used by RenderSVG. Defaults to "monospace".
+func WithSVGFontFamily(family string) SVGOption {
+	return func(o *svgOptions) {
+		o.fontFamily = family
+	}
+}
+
+// RenderSVG renders the emulated terminal's screen as an SVG image, including colors, bold, italic, and underline.
+// Each run of text is stretched to its cells' width, so the grid is preserved regardless of the font used to view it.
+// Output is deterministic for a given screen and options, making it suitable for visual regression diffs and
+// documentation. Pending output is flushed first.
+func RenderSVG(m *Mimic, opts ...SVGOption) string {
+	o := &svgOptions{fontSize: 14, fontFamily: "monospace"}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	cellWidth := o.fontSize * 0.6
+	lineHeight := o.fontSize * 1.2
+	screen := m.Screen()
+	columns := 0
+	if len(screen) > 0 {
+		columns = len(screen[0])
+	}
+	width, height := float64(columns)*cellWidth, float64(len(screen))*lineHeight
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %[1]s %[2]s" font-family="%s" font-size="%s">`+"\n",
+		svgNumber(width), svgNumber(height), html.EscapeString(o.fontFamily), svgNumber(o.fontSize))
+	fmt.Fprintf(&sb, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", defaultBackgroundHex)
+
+	for y, row := range screen {
+		top := float64(y) * lineHeight
+		for _, run := range styledRuns(trimRow(row)) {
+			x := float64(run.column) * cellWidth
+			runWidth := float64(textWidth(run.text)) * cellWidth
+			if bg := run.backgroundHex(); bg != defaultBackgroundHex {
+				fmt.Fprintf(&sb, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`+"\n",
+					svgNumber(x), svgNumber(top), svgNumber(runWidth), svgNumber(lineHeight), bg)
+			}
+			if strings.TrimSpace(run.text) == "" && !run.cell.Underline {
+				continue
+			}
+
+			attributes := []string{
+				`x="` + svgNumber(x) + `"`,
+				`y="` + svgNumber(top+o.fontSize) + `"`,
+				`fill="` + run.foregroundHex() + `"`,
+				`textLength="` + svgNumber(runWidth) + `"`,
+				`lengthAdjust="spacingAndGlyphs"`,
+			}
+			if run.cell.Bold {
+				attributes = append(attributes, `font-weight="bold"`)
+			}
+			if run.cell.Italic {
+				attributes = append(attributes, `font-style="italic"`)
+			}
+			if run.cell.Underline {
+				attributes = append(attributes, `text-decoration="underline"`)
+			}
+			fmt.Fprintf(&sb, `<text xml:space="preserve" %s>%s</text>`+"\n", strings.Join(attributes, " "), html.EscapeString(run.text))
+		}
+	}
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// svgNumber formats f to at most two decimal places, for stable output free of floating point noise
+func svgNumber(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,104 @@
+package mimic
+
+import (
+	"context"
+
+	"github.com/jimschubert/mimic/internal"
+	"github.com/jimschubert/mimic/internal/expect"
+	"github.com/jimschubert/stripansi"
+)
+
+// SwitchCase pairs criteria with a handler to invoke when the criteria matches. Construct via CaseString or CasePattern.
+type SwitchCase struct {
+	criteria string
+	matcher  func(m *Mimic) expect.Matcher
+	handle   func(output string) error
+	// err is reported by the switch in place of waiting, e.g. for an invalid pattern
+	err error
+}
+
+// CaseString creates a SwitchCase which matches s as Mimic.ExpectString does, invoking handler when it matches.
+// A nil handler does nothing.
+func CaseString(s string, handler func() error) SwitchCase {
+	return SwitchCase{
+		criteria: s,
+		matcher: func(m *Mimic) expect.Matcher {
+			return m.stringMatcher(s)
+		},
+		handle: func(string) error {
+			if handler == nil {
+				return nil
+			}
+			return handler()
+		},
+	}
+}
+
+// CasePattern creates a SwitchCase which matches pattern as Mimic.ExpectPattern does, invoking handler with the result
+// when it matches. A nil handler does nothing. If pattern is invalid, the switch returns an error wrapping
+// ErrInvalidPattern rather than waiting.
+func CasePattern(pattern string, handler func(MatchResult) error) SwitchCase {
+	re, err := CompilePattern(pattern)
+	if err != nil {
+		return SwitchCase{criteria: pattern, err: err}
+	}
+	return SwitchCase{
+		criteria: pattern,
+		matcher: func(*Mimic) expect.Matcher {
+			return &internal.RegexpMatcher{Re: re}
+		},
+		handle: func(output string) error {
+			if handler == nil {
+				return nil
+			}
+			return handler(newMatchResult(pattern, re, output))
+		},
+	}
+}
+
+// ExpectSwitch waits for the criteria of any case to match, then invokes the handler of the case which matched first,
+// returning its error. This supports flows where an application may take one of several paths, like the case blocks
+// of classic expect:
+//
+//	err := m.ExpectSwitch(
+//		mimic.CaseString("overwrite? [y/N]", func() error {
+//			_, err := m.WriteString("y\r")
+//			return err
+//		}),
+//		mimic.CaseString("Saved", nil),
+//	)
+//
+// See Mimic.ExpectSwitchContext.
+func (m *Mimic) ExpectSwitch(cases ...SwitchCase) error {
+	return m.ExpectSwitchContext(context.Background(), cases...)
+}
+
+// ExpectSwitchContext is Mimic.ExpectSwitch, abandoning the expectation with ctx's error if ctx is done before a case
+// matches.
+func (m *Mimic) ExpectSwitchContext(ctx context.Context, cases ...SwitchCase) error {
+	matchers := make([]expect.Matcher, 0, len(cases))
+	criteria := make([]string, 0, len(cases))
+	for _, c := range cases {
+		if c.err != nil {
+			m.transcript.record("ExpectSwitch", []string{c.criteria}, "", c.err)
+			return c.err
+		}
+		matchers = append(matchers, c.matcher(m))
+		criteria = append(criteria, c.criteria)
+	}
+
+	output, matched, err := m.expect(ctx, matchers...)
+	output = stripansi.String(output)
+	err = m.patternError(criteria, output, err, false)
+	m.transcript.record("ExpectSwitch", criteria, output, err)
+	if err != nil {
+		return err
+	}
+
+	for i, matcher := range matchers {
+		if matcher == matched {
+			return cases[i].handle(output)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package mimic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_ExpectSwitch(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{name: "prompted", script: `printf "overwrite? [y/N] "; read answer; echo "answered $answer"`, want: "answered y"},
+		{name: "not prompted", script: `echo "Saved config.yml"`, want: "Saved"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMimic(WithIdleTimeout(2 * time.Second))
+			assert.NoError(t, err)
+			defer m.Close()
+
+			p, err := m.Spawn(context.Background(), "sh", "-c", tt.script)
+			assert.NoError(t, err)
+
+			var path string
+			err = m.ExpectSwitch(
+				CaseString("overwrite? [y/N]", func() error {
+					path = "answered y"
+					_, err := m.WriteString("y\r")
+					if err != nil {
+						return err
+					}
+					return m.ExpectString("answered y")
+				}),
+				CasePattern(`Saved (?P<file>\S+)\s`, func(result MatchResult) error {
+					path = "Saved"
+					assert.Equal(t, "config.yml", result.Group("file"))
+					return nil
+				}),
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, path)
+			assert.NoError(t, p.Wait())
+		})
+	}
+}
+
+func TestMimic_ExpectSwitch_errors(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(50 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, _ = m.Tty().WriteString("Error: disk full")
+
+	failure := errors.New("handled failure")
+	err = m.ExpectSwitch(
+		CaseString("Success", nil),
+		CaseString("Error:", func() error { return failure }),
+	)
+	assert.ErrorIs(t, err, failure)
+
+	assert.Error(t, m.ExpectSwitch(CaseString("Success", nil)), "no case matching should time out")
+
+	err = m.ExpectSwitch(CaseString("Success", nil), CasePattern(`Error: (`, nil))
+	assert.ErrorIs(t, err, ErrInvalidPattern)
+}
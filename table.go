@@ -0,0 +1,344 @@
+package mimic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// tableGutter is the number of blank columns which separates the columns of a column-aligned table. Single spaces are
+// retained within cells, e.g. "2 days ago".
+const tableGutter = 2
+
+const (
+	// tableBars separate the cells of a box-drawn table
+	tableBars = "|│┃║╎╏┆┇┊┋"
+	// tableBorders are the characters of a box-drawn table's borders, along with tableBars
+	tableBorders = "-=+:─━═┄┅┈┉┌┐└┘├┤┬┴┼┍┎┑┒┕┖┙┚┝┞┟┠┡┢┥┦┧┨┩┪┭┮┯┰┱┲┵┶┷┸┹┺┽┾┿╀╁╂╃╄╅╆╇╈╉╊╋╒╓╔╕╖╗╘╙╚╛╜╝╞╟╠╡╢╣╤╥╦╧╨╩╪╫╬╭╮╯╰"
+)
+
+// Table is a table detected in the emulated terminal's view, as rows of cells. Each cell's text is trimmed of
+// surrounding whitespace. See Mimic.Tables.
+type Table [][]string
+
+// Column returns the cells below the first row's cell equal to header, or nil if the first row has no such cell
+func (t Table) Column(header string) []string {
+	if len(t) == 0 {
+		return nil
+	}
+	for i, cell := range t[0] {
+		if cell != header {
+			continue
+		}
+		column := make([]string, 0, len(t)-1)
+		for _, row := range t[1:] {
+			if i < len(row) {
+				column = append(column, row[i])
+			} else {
+				column = append(column, "")
+			}
+		}
+		return column
+	}
+	return nil
+}
+
+// Tables returns the tables in the emulated terminal's view, from top to bottom, after flushing pending output. Two
+// kinds of tables are detected:
+//
+//   - box-drawn tables, whose cells are separated by vertical bars (e.g. │ or |) aligned on consecutive rows, optionally
+//     with borders drawn using characters such as ─, ┼, +, and -
+//   - column-aligned tables, such as those printed by kubectl or docker, where consecutive rows share gutters of at
+//     least two blank columns
+//
+// Detection is heuristic: output which happens to be aligned may be reported as a table, and a row which crosses a
+// table's gutters (e.g. a long message following the table) ends it.
+func (m *Mimic) Tables() []Table {
+	_ = m.Flush()
+	return m.tables()
+}
+
+// ContainsTableRow determines if a table in the emulated terminal's view (see Mimic.Tables) has a row containing cells,
+// in order. Other cells may precede, follow, or lie between them, so ContainsTableRow("web", "Running") matches the row
+// "web  1/1  Running  0  5m" of a table listing pods.
+func (m *Mimic) ContainsTableRow(cells ...string) bool {
+	err := m.Flush()
+	if err != nil {
+		m.debug("ContainsTableRow failed to flush", "error", err)
+		return false
+	}
+	return hasTableRow(m.tables(), cells)
+}
+
+// ExpectTableRow waits for a table in the emulated terminal's view to have a row containing cells, in order (see
+// Mimic.ContainsTableRow), returning an error if one doesn't appear before the idle timeout (see WithIdleTimeout and
+// WithCallTimeout).
+//
+//	err := m.ExpectTableRow("NAME", "STATUS")
+func (m *Mimic) ExpectTableRow(cells ...string) error {
+	_, _, err := m.expect(context.Background(), &tableRowMatcher{m: m, cells: cells})
+	if err != nil {
+		return fmt.Errorf("no table row contains %q: %w", cells, err)
+	}
+	return nil
+}
+
+// tableRowMatcher matches once a table in the view has a row containing cells, regardless of the content read
+type tableRowMatcher struct {
+	m     *Mimic
+	cells []string
+}
+
+func (t *tableRowMatcher) Match(_ interface{}) bool {
+	return hasTableRow(t.m.tables(), t.cells)
+}
+
+func (t *tableRowMatcher) Criteria() interface{} {
+	return t.cells
+}
+
+// hasTableRow reports whether any row of tables contains cells, in order
+func hasTableRow(tables []Table, cells []string) bool {
+	if len(cells) == 0 {
+		return false
+	}
+	for _, table := range tables {
+		for _, row := range table {
+			next := 0
+			for _, cell := range row {
+				if next < len(cells) && cell == cells[next] {
+					next++
+				}
+			}
+			if next == len(cells) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// tables detects the tables of the view
+func (m *Mimic) tables() []Table {
+	m.terminal.Lock()
+	columns, rows := m.terminal.Size()
+	grid := make([][]rune, rows)
+	for y := range grid {
+		grid[y] = make([]rune, columns)
+		for x := range grid[y] {
+			grid[y][x] = m.terminal.Cell(x, y).Char
+		}
+	}
+	m.terminal.Unlock()
+
+	return detectTables(grid)
+}
+
+// detectTables detects the tables in grid, indexed by row then column
+func detectTables(grid [][]rune) []Table {
+	type located struct {
+		row   int
+		table Table
+	}
+	var found []located
+	used := make([]bool, len(grid))
+
+	for start := 0; start < len(grid); {
+		end, table := boxTable(grid, start)
+		if table == nil {
+			start++
+			continue
+		}
+		for y := start; y < end; y++ {
+			used[y] = true
+		}
+		found = append(found, located{row: start, table: table})
+		start = end
+	}
+
+	for start := 0; start < len(grid); {
+		end, table := alignedTable(grid, used, start)
+		if table == nil {
+			start++
+			continue
+		}
+		found = append(found, located{row: start, table: table})
+		start = end
+	}
+
+	tables := make([]Table, 0, len(found))
+	for y := range grid {
+		for _, f := range found {
+			if f.row == y {
+				tables = append(tables, f.table)
+			}
+		}
+	}
+	return tables
+}
+
+// boxTable detects a box-drawn table beginning at row start, returning the row following it
+func boxTable(grid [][]rune, start int) (int, Table) {
+	var table Table
+	var bars []int
+	borders := 0
+	end := start
+	for ; end < len(grid); end++ {
+		if isBorderRow(grid[end]) {
+			borders++
+			continue
+		}
+		positions := barPositions(grid[end])
+		if len(positions) == 0 || bars != nil && !equalPositions(bars, positions) {
+			break
+		}
+		bars = positions
+		table = append(table, boxCells(grid[end], positions))
+	}
+	if len(table) < 2 && (len(table) < 1 || borders < 1) || isBorderRow(grid[start]) && len(table) == 0 {
+		return start, nil
+	}
+	return end, table
+}
+
+// isBorderRow reports whether row is a border of a box-drawn table, consisting only of border characters and spaces
+func isBorderRow(row []rune) bool {
+	horizontal := false
+	for _, r := range row {
+		switch {
+		case r == ' ' || strings.ContainsRune(tableBars, r):
+		case strings.ContainsRune(tableBorders, r):
+			horizontal = true
+		default:
+			return false
+		}
+	}
+	return horizontal
+}
+
+// barPositions are the columns of row holding the vertical bars which separate cells
+func barPositions(row []rune) []int {
+	var positions []int
+	for x, r := range row {
+		if strings.ContainsRune(tableBars, r) {
+			positions = append(positions, x)
+		}
+	}
+	return positions
+}
+
+func equalPositions(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// boxCells splits row into the cells between its bars, omitting the space outside a table's outer border
+func boxCells(row []rune, bars []int) []string {
+	cells := make([]string, 0, len(bars)+1)
+	from := 0
+	for _, bar := range append(bars[:len(bars):len(bars)], len(row)) {
+		cells = append(cells, cellText(row[from:bar]))
+		from = bar + 1
+	}
+	if cells[0] == "" {
+		cells = cells[1:]
+	}
+	if len(cells) > 0 && cells[len(cells)-1] == "" {
+		cells = cells[:len(cells)-1]
+	}
+	return cells
+}
+
+// alignedTable detects a column-aligned table beginning at row start, returning the row following it
+func alignedTable(grid [][]rune, used []bool, start int) (int, Table) {
+	usable := func(y int) bool {
+		return y < len(grid) && !used[y] && strings.TrimSpace(string(grid[y])) != ""
+	}
+	if !usable(start) {
+		return start, nil
+	}
+
+	end := start + 1
+	for usable(end) && len(tableColumns(grid[start:end+1])) >= 2 {
+		end++
+	}
+	columns := tableColumns(grid[start:end])
+	if end-start < 2 || len(columns) < 2 {
+		return start, nil
+	}
+	if end-start > 2 && len(tableColumns(grid[start+1:end])) > len(columns) {
+		// the first row crosses the gutters of those which follow, e.g. a command line above its output
+		return start, nil
+	}
+
+	table := make(Table, 0, end-start)
+	for _, row := range grid[start:end] {
+		cells := make([]string, 0, len(columns))
+		for _, column := range columns {
+			cells = append(cells, cellText(columnRange(row, column[0], column[1])))
+		}
+		table = append(table, cells)
+	}
+	return end, table
+}
+
+// tableColumns finds the [start, end) columns of rows separated by gutters which are blank in every row
+func tableColumns(rows [][]rune) [][2]int {
+	width := 0
+	for _, row := range rows {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	blank := make([]bool, width)
+	for x := range blank {
+		blank[x] = true
+		for _, row := range rows {
+			if x < len(row) && row[x] != ' ' {
+				blank[x] = false
+				break
+			}
+		}
+	}
+
+	var columns [][2]int
+	for x := 0; x < width; {
+		if blank[x] {
+			x++
+			continue
+		}
+		begin, gap := x, 0
+		for ; x < width && gap < tableGutter; x++ {
+			if blank[x] {
+				gap++
+			} else {
+				gap = 0
+			}
+		}
+		columns = append(columns, [2]int{begin, x - gap})
+	}
+	return columns
+}
+
+// columnRange is row's [start, end) columns, which are shorter or empty if row is
+func columnRange(row []rune, start, end int) []rune {
+	if end > len(row) {
+		end = len(row)
+	}
+	if start > end {
+		start = end
+	}
+	return row[start:end]
+}
+
+// cellText is the trimmed text of a cell's columns
+func cellText(columns []rune) string {
+	return strings.TrimSpace(withoutSpacers(string(columns)))
+}
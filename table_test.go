@@ -0,0 +1,126 @@
+package mimic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectTables(t *testing.T) {
+	grid := func(lines ...string) [][]rune {
+		rows := make([][]rune, len(lines))
+		for i, line := range lines {
+			rows[i] = []rune(line)
+		}
+		return rows
+	}
+
+	tests := []struct {
+		name  string
+		lines []string
+		want  []Table
+	}{
+		{
+			name: "column-aligned",
+			lines: []string{
+				"$ kubectl get pods",
+				"NAME   READY   STATUS    AGE",
+				"web    1/1     Running   2 days ago",
+				"db     0/1     Pending   5m",
+				"",
+				"done",
+			},
+			want: []Table{{
+				{"NAME", "READY", "STATUS", "AGE"},
+				{"web", "1/1", "Running", "2 days ago"},
+				{"db", "0/1", "Pending", "5m"},
+			}},
+		},
+		{
+			name: "box-drawn",
+			lines: []string{
+				"┌──────┬───────┐",
+				"│ Name │ Age   │",
+				"├──────┼───────┤",
+				"│ Ann  │ 31    │",
+				"│ Bob  │       │",
+				"└──────┴───────┘",
+			},
+			want: []Table{{{"Name", "Age"}, {"Ann", "31"}, {"Bob", ""}}},
+		},
+		{
+			name: "markdown",
+			lines: []string{
+				"| Key | Value |",
+				"|-----|-------|",
+				"| a   | 1     |",
+			},
+			want: []Table{{{"Key", "Value"}, {"a", "1"}}},
+		},
+		{
+			name: "in order",
+			lines: []string{
+				"+---+---+",
+				"| x | y |",
+				"+---+---+",
+				"",
+				"ID  NAME",
+				"1   one",
+			},
+			want: []Table{{{"x", "y"}}, {{"ID", "NAME"}, {"1", "one"}}},
+		},
+		{
+			name:  "prose",
+			lines: []string{"Hello, world.", "Run cat a | grep b to search."},
+			want:  []Table{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, detectTables(grid(tt.lines...)))
+		})
+	}
+}
+
+func TestTable_Column(t *testing.T) {
+	table := Table{{"NAME", "STATUS"}, {"web", "Running"}, {"db"}}
+	assert.Equal(t, []string{"Running", ""}, table.Column("STATUS"))
+	assert.Nil(t, table.Column("AGE"))
+	assert.Nil(t, Table{}.Column("NAME"))
+}
+
+func TestMimic_Tables(t *testing.T) {
+	m, err := NewMimic(WithSize(6, 40), WithIdleTimeout(50*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Tty().WriteString("名前    状態\r\n世界    \x1b[32mok\x1b[0m\r\n")
+	assert.NoError(t, err)
+
+	tables := m.Tables()
+	assert.Equal(t, []Table{{{"名前", "状態"}, {"世界", "ok"}}}, tables, "wide runes and styles shouldn't affect columns")
+	assert.True(t, m.ContainsTableRow("世界", "ok"))
+	assert.False(t, m.ContainsTableRow("ok", "世界"), "cells should be matched in order")
+	assert.False(t, m.ContainsTableRow())
+}
+
+func TestMimic_ExpectTableRow(t *testing.T) {
+	m, err := NewMimic(WithIdleTimeout(100 * time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_, _ = m.Tty().WriteString("NAME   READY   STATUS\r\n")
+		time.Sleep(20 * time.Millisecond)
+		_, _ = m.Tty().WriteString("web    1/1     Running\r\n")
+	}()
+
+	assert.NoError(t, m.ExpectTableRow("NAME", "STATUS"))
+	assert.NoError(t, m.ExpectTableRow("web", "Running"))
+
+	err = m.ExpectTableRow("db", "Running")
+	assert.ErrorIs(t, err, ErrExpectTimeout)
+	assert.ErrorContains(t, err, `"db"`)
+}
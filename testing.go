@@ -0,0 +1,117 @@
+package mimic
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// deadlineFraction bounds each timeout of a Mimic created by NewTestMimic to this fraction of the time remaining
+// before the test's deadline, leaving room for the test to report a failure before the test binary panics.
+const deadlineFraction = 4
+
+// maxDeadlineMargin bounds how long before the test's deadline expectations of a Mimic created by NewTestMimic are
+// abandoned
+const maxDeadlineMargin = 5 * time.Second
+
+// NewTestMimic creates a Mimic bound to the lifecycle of t, as ForTest does. Additionally, when t reports a deadline
+// (as *testing.T does when tests are run with -timeout), the idle and flush timeouts are capped at a fraction of the
+// time remaining, so that an expectation which never matches fails the test with its view rather than hanging until
+// the test binary is killed. Expectations still in progress shortly before the deadline are abandoned, failing with
+// ErrExpectTimeout and the view at that point.
+func NewTestMimic(t testing.TB, opts ...Option) *Mimic {
+	t.Helper()
+
+	if d, ok := t.(interface{ Deadline() (time.Time, bool) }); ok {
+		if deadline, ok := d.Deadline(); ok {
+			opts = append(opts, withDeadline(deadline))
+		}
+	}
+	return ForTest(t, opts...)
+}
+
+// withDeadline caps timeouts at a fraction of the time remaining until deadline, and abandons expectations shortly
+// before deadline. It must be applied after any options which configure timeouts.
+func withDeadline(deadline time.Time) Option {
+	return func(opt *mimicOpt) {
+		limit := time.Until(deadline) / deadlineFraction
+		if limit <= 0 {
+			limit = time.Millisecond
+		}
+		if opt.maxIdleTimeout > limit {
+			opt.maxIdleTimeout = limit
+		}
+		if opt.flushTimeout > limit {
+			opt.flushTimeout = limit
+		}
+		if opt.idleDuration > limit {
+			opt.idleDuration = limit
+		}
+
+		margin := limit
+		if margin > maxDeadlineMargin {
+			margin = maxDeadlineMargin
+		}
+		opt.deadline = deadline.Add(-margin)
+	}
+}
+
+// ForTest creates a Mimic bound to the lifecycle of t. The Mimic is closed via t.Cleanup, debug logs
+// (see the DEBUG environment variable) are routed to t.Log, and the formatted terminal view is logged if the test fails.
+// Construction errors fail the test immediately.
+func ForTest(t testing.TB, opts ...Option) *Mimic {
+	t.Helper()
+
+	logger := &testLogWriter{t: t}
+	m, err := NewMimic(append([]Option{withLogOutput(logger)}, opts...)...)
+	if err != nil {
+		t.Fatalf("unable to construct mimic: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if t.Failed() {
+			_ = m.Flush()
+			v := Viewer{Mimic: m, StripAnsi: true, Trim: true}
+			t.Logf("terminal view at failure:\n%s", v.String())
+			if entries := m.Transcript(); len(entries) > 0 {
+				t.Logf("transcript:\n%s", entries.String())
+			}
+		}
+		_ = m.Close()
+		logger.stop()
+	})
+
+	return m
+}
+
+// withLogOutput directs debug logs to w
+func withLogOutput(w io.Writer) Option {
+	return func(opt *mimicOpt) {
+		opt.logOutput = w
+	}
+}
+
+// testLogWriter forwards writes to t.Log until stopped. Logging to t after the test completes panics, and
+// background goroutines of the underlying console may log after the test's cleanup.
+type testLogWriter struct {
+	t       testing.TB
+	mu      sync.Mutex
+	stopped bool
+}
+
+func (w *testLogWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.stopped {
+		w.t.Log(strings.TrimSuffix(string(p), "\n"))
+	}
+	return len(p), nil
+}
+
+func (w *testLogWriter) stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stopped = true
+}
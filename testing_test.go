@@ -0,0 +1,123 @@
+package mimic
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failingTB struct {
+	testing.TB
+	cleanups []func()
+	logs     []string
+}
+
+func (f *failingTB) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+func (f *failingTB) Failed() bool {
+	return true
+}
+
+func (f *failingTB) Logf(format string, args ...interface{}) {
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+
+func TestForTest(t *testing.T) {
+	var m *Mimic
+	t.Run("constructs", func(t *testing.T) {
+		m = ForTest(t)
+		_, err := m.WriteString("Hello")
+		assert.NoError(t, err)
+		assert.NoError(t, m.ExpectString("Hello"))
+	})
+
+	_, err := m.WriteString("closed")
+	assert.Error(t, err, "mimic should be closed once the test completes")
+}
+
+func TestForTest_logsViewOnFailure(t *testing.T) {
+	tb := &failingTB{TB: t}
+	m := ForTest(tb)
+	_, err := m.Tty().WriteString("Something went wrong")
+	assert.NoError(t, err)
+
+	for i := len(tb.cleanups) - 1; i >= 0; i-- {
+		tb.cleanups[i]()
+	}
+
+	assert.Equal(t, []string{"terminal view at failure:\nSomething went wrong"}, tb.logs)
+}
+
+func TestForTest_logsTranscriptOnFailure(t *testing.T) {
+	tb := &failingTB{TB: t}
+	m := ForTest(tb, WithTranscript())
+	_, err := m.WriteString("hi")
+	assert.NoError(t, err)
+
+	for i := len(tb.cleanups) - 1; i >= 0; i-- {
+		tb.cleanups[i]()
+	}
+
+	assert.Len(t, tb.logs, 2)
+	assert.Equal(t, "transcript:\n1. +0.000s WriteString(\"hi\")\n", tb.logs[1])
+}
+
+type deadlineTB struct {
+	testing.TB
+	deadline time.Time
+}
+
+func (d *deadlineTB) Deadline() (time.Time, bool) {
+	return d.deadline, true
+}
+
+func TestNewTestMimic(t *testing.T) {
+	var m *Mimic
+	t.Run("constructs", func(t *testing.T) {
+		m = NewTestMimic(t, WithIdleTimeout(time.Second))
+		assert.Equal(t, time.Second, m.maxIdleWait)
+	})
+
+	_, err := m.WriteString("closed")
+	assert.Error(t, err, "mimic should be closed once the test completes")
+}
+
+func TestNewTestMimic_scalesTimeoutsFromDeadline(t *testing.T) {
+	tb := &deadlineTB{TB: t, deadline: time.Now().Add(2 * time.Second)}
+	m := NewTestMimic(tb, WithIdleTimeout(time.Minute))
+
+	assert.LessOrEqual(t, m.maxIdleWait, 500*time.Millisecond)
+	assert.Greater(t, m.maxIdleWait, 400*time.Millisecond)
+	assert.Equal(t, DefaultFlushTimeout, m.flushTimeout, "timeouts within the limit are unchanged")
+}
+
+func TestNewTestMimic_abandonsExpectationsBeforeDeadline(t *testing.T) {
+	tb := &deadlineTB{TB: t, deadline: time.Now().Add(2 * time.Second)}
+	m := NewTestMimic(tb, WithSize(5, 20))
+	defer m.Close()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		// continuous output keeps the expectation from idling out
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				_, _ = m.Tty().WriteString(".")
+				time.Sleep(5 * time.Millisecond)
+			}
+		}
+	}()
+
+	err := m.ExpectString("never")
+	assert.ErrorIs(t, err, ErrExpectTimeout)
+	assert.Contains(t, err.Error(), "abandoned at the deadline")
+	assert.Contains(t, err.Error(), "View:")
+	assert.True(t, time.Now().Before(tb.deadline), "expectation should be abandoned before the test's deadline")
+}
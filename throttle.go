@@ -0,0 +1,88 @@
+package mimic
+
+import (
+	"sync"
+	"time"
+)
+
+// WithBaudRate limits the rate at which the program's output reaches the view to that of a serial line at baud bits per
+// second, with 10 bits (8N1 framing) per byte: e.g. WithBaudRate(9600) renders 960 bytes per second. This reproduces
+// rendering which only misbehaves on slow connections, where output arrives partially. See WithThroughput.
+func WithBaudRate(baud int) Option {
+	return WithThroughput(baud / 10)
+}
+
+// WithThroughput limits the rate at which the program's output reaches the view to bytesPerSecond. A value of zero or
+// less removes the limit.
+//
+// Output is throttled as it's read, so operations which read output, such as expectations and Flush, take longer
+// (and may time out), while the program under test writes at full speed. Input isn't throttled.
+func WithThroughput(bytesPerSecond int) Option {
+	return func(opt *mimicOpt) {
+		opt.throttle.bytesPerSecond = bytesPerSecond
+	}
+}
+
+// WithChunkDelay delivers the program's output in chunks of size bytes, pausing for delay before each chunk reaches the
+// view, as output arrives over a network in packets. This allows testing progress indicators and other incremental
+// rendering with realistic pacing. It may be combined with WithThroughput.
+func WithChunkDelay(size int, delay time.Duration) Option {
+	return func(opt *mimicOpt) {
+		opt.throttle.chunkSize = size
+		opt.throttle.chunkDelay = delay
+	}
+}
+
+type throttleOpt struct {
+	bytesPerSecond int
+	chunkSize      int
+	chunkDelay     time.Duration
+}
+
+func (o throttleOpt) enabled() bool {
+	return o.bytesPerSecond > 0 || (o.chunkSize > 0 && o.chunkDelay > 0)
+}
+
+// throttle paces output, delaying each write before the terminal observes it
+type throttle struct {
+	mu           sync.Mutex
+	byteDuration time.Duration
+	chunkSize    int
+	chunkDelay   time.Duration
+	// next is the earliest time at which further output may be rendered
+	next time.Time
+	// chunked is the number of bytes rendered from the current chunk
+	chunked int
+}
+
+func newThrottle(o throttleOpt) *throttle {
+	t := &throttle{}
+	if o.bytesPerSecond > 0 {
+		t.byteDuration = time.Second / time.Duration(o.bytesPerSecond)
+	}
+	if o.chunkSize > 0 && o.chunkDelay > 0 {
+		t.chunkSize = o.chunkSize
+		t.chunkDelay = o.chunkDelay
+	}
+	return t
+}
+
+func (t *throttle) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if t.next.Before(now) {
+		// output doesn't catch up on time spent idle
+		t.next = now
+	}
+	if t.chunkSize > 0 {
+		if t.chunked == 0 {
+			t.next = t.next.Add(t.chunkDelay)
+		}
+		t.chunked = (t.chunked + len(p)) % t.chunkSize
+	}
+	t.next = t.next.Add(time.Duration(len(p)) * t.byteDuration)
+	time.Sleep(time.Until(t.next))
+	return len(p), nil
+}
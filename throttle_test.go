@@ -0,0 +1,49 @@
+package mimic
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithThroughput(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithThroughput(1000), WithIdleTimeout(time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	began := time.Now()
+	_, err = m.Device().Write([]byte(strings.Repeat(".", 95) + "done"))
+	assert.NoError(t, err)
+	assert.NoError(t, m.ExpectString("done"))
+	assert.GreaterOrEqual(t, time.Since(began), 90*time.Millisecond, "99 bytes should take ~99ms at 1000 bytes per second")
+}
+
+func TestWithBaudRate(t *testing.T) {
+	opt := &mimicOpt{}
+	WithBaudRate(9600)(opt)
+	assert.Equal(t, 960, opt.throttle.bytesPerSecond)
+	assert.Equal(t, time.Second/960, newThrottle(opt.throttle).byteDuration)
+}
+
+func TestWithChunkDelay(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithChunkDelay(4, 40*time.Millisecond), WithIdleTimeout(time.Second))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	began := time.Now()
+	_, err = m.Device().Write([]byte("abcdefgh"))
+	assert.NoError(t, err)
+	assert.NoError(t, m.ExpectString("abcd"))
+	assert.GreaterOrEqual(t, time.Since(began), 40*time.Millisecond, "the first chunk should be delayed")
+	assert.NotContains(t, m.terminal.String(), "efgh", "the second chunk should not have arrived yet")
+	assert.NoError(t, m.ExpectString("efgh"))
+	assert.GreaterOrEqual(t, time.Since(began), 80*time.Millisecond, "the second chunk should be delayed")
+}
+
+func TestThrottle_disabled(t *testing.T) {
+	assert.False(t, throttleOpt{}.enabled())
+	assert.False(t, throttleOpt{chunkSize: 10}.enabled(), "chunks without a delay aren't throttled")
+	assert.True(t, throttleOpt{bytesPerSecond: 1}.enabled())
+}
@@ -0,0 +1,116 @@
+package mimic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/jimschubert/mimic/internal/expect"
+)
+
+// Trace event names, as reported in TraceEvent.Event
+const (
+	// TraceWrite is input written to the terminal, e.g. via Mimic.WriteString
+	TraceWrite = "write"
+	// TraceRead is output read from the terminal into the view
+	TraceRead = "read"
+	// TraceExpect is the start of an expectation, listing its criteria
+	TraceExpect = "expect"
+	// TraceMatch is an expectation which matched, listing the criteria which matched
+	TraceMatch = "match"
+	// TraceMismatch is an expectation which ended without matching, e.g. on timeout
+	TraceMismatch = "mismatch"
+	// TraceFlush is a flush of pending output into the view, e.g. via Mimic.Flush
+	TraceFlush = "flush"
+	// TraceIdle is a wait for the terminal to become idle via Mimic.WaitForIdle
+	TraceIdle = "idle"
+)
+
+// WithTrace writes a timestamped event to w for each interaction with the terminal: writes, reads, the start and
+// outcome of each expectation, flushes, and waits for idle. Events are written as JSON lines, one TraceEvent per line,
+// which allows analyzing the timing of flaky expectations:
+//
+//	{"time":"2024-01-02T15:04:05.000001Z","elapsed":0.0124,"event":"expect","criteria":["name?"]}
+//
+// Tracing stops at the first error writing to w.
+func WithTrace(w io.Writer) Option {
+	return func(opt *mimicOpt) {
+		opt.trace = w
+	}
+}
+
+// TraceEvent is a single event written by WithTrace
+type TraceEvent struct {
+	// Time at which the event occurred
+	Time time.Time `json:"time"`
+	// Elapsed is the number of seconds since the Mimic was created
+	Elapsed float64 `json:"elapsed"`
+	// Event names the kind of event, e.g. TraceWrite
+	Event string `json:"event"`
+	// Data written or read, for TraceWrite and TraceRead events
+	Data string `json:"data,omitempty"`
+	// Criteria of an expectation, for TraceExpect and TraceMatch events
+	Criteria []string `json:"criteria,omitempty"`
+	// Duration of the operation in seconds, for events which complete an operation
+	Duration float64 `json:"duration,omitempty"`
+	// Error which ended the operation, if any
+	Error string `json:"error,omitempty"`
+}
+
+// tracer writes trace events
+type tracer struct {
+	mu      sync.Mutex
+	enc     *json.Encoder
+	started time.Time
+	failed  bool
+}
+
+func newTracer(w io.Writer) *tracer {
+	return &tracer{enc: json.NewEncoder(w), started: time.Now()}
+}
+
+func (t *tracer) Write(p []byte) (int, error) {
+	t.record(TraceEvent{Event: TraceRead, Data: string(p)})
+	return len(p), nil
+}
+
+// completed records an event for an operation which began at began, ending with err
+func (t *tracer) completed(event string, began time.Time, criteria []string, err error) {
+	t.record(TraceEvent{Event: event, Criteria: criteria, Duration: time.Since(began).Seconds(), Error: errorString(err)})
+}
+
+func (t *tracer) record(e TraceEvent) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.failed {
+		return
+	}
+
+	e.Time = time.Now()
+	e.Elapsed = e.Time.Sub(t.started).Seconds()
+	if err := t.enc.Encode(e); err != nil {
+		t.failed = true
+	}
+}
+
+// errorString is the message of err, or an empty string if err is nil
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// criteriaOf describes the criteria of matchers for a trace
+func criteriaOf(matchers ...expect.Matcher) []string {
+	criteria := make([]string, 0, len(matchers))
+	for _, matcher := range matchers {
+		criteria = append(criteria, fmt.Sprint(matcher.Criteria()))
+	}
+	return criteria
+}
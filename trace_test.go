@@ -0,0 +1,71 @@
+package mimic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func traceEvents(t *testing.T, trace string) []TraceEvent {
+	var events []TraceEvent
+	for _, line := range strings.Split(strings.TrimSpace(trace), "\n") {
+		var e TraceEvent
+		assert.NoError(t, json.Unmarshal([]byte(line), &e), line)
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestWithTrace(t *testing.T) {
+	var buf bytes.Buffer
+	m, err := NewMimic(WithTrace(&buf), WithIdleTimeout(50*time.Millisecond), WithIdleDuration(10*time.Millisecond), WithIdleStrategy(OutputActivity))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.WriteString("hi")
+	assert.NoError(t, err)
+	_, err = m.Tty().WriteString("Hello")
+	assert.NoError(t, err)
+	assert.NoError(t, m.ExpectString("Hello"))
+	assert.Error(t, m.ExpectString("Goodbye"))
+	assert.NoError(t, m.Flush())
+	_ = m.WaitForIdle(context.Background())
+
+	events := traceEvents(t, buf.String())
+	names := make([]string, 0, len(events))
+	var read strings.Builder
+	for _, e := range events {
+		if e.Event == TraceRead {
+			read.WriteString(e.Data)
+			continue
+		}
+		names = append(names, e.Event)
+	}
+	assert.Equal(t, []string{TraceWrite, TraceExpect, TraceMatch, TraceExpect, TraceMismatch, TraceFlush, TraceIdle}, names)
+	// the echo of input may arrive before or after output written directly to the tty
+	assert.Len(t, read.String(), len("hiHello"))
+	assert.Contains(t, read.String(), "hi", "output includes the echo of input")
+	assert.Contains(t, read.String(), "Hello")
+
+	assert.Equal(t, "hi", events[0].Data)
+	for i := 1; i < len(events); i++ {
+		assert.GreaterOrEqual(t, events[i].Elapsed, events[i-1].Elapsed, "events should be in chronological order")
+	}
+
+	var expectations []TraceEvent
+	for _, e := range events {
+		if e.Event == TraceExpect || e.Event == TraceMatch || e.Event == TraceMismatch {
+			expectations = append(expectations, e)
+		}
+	}
+	assert.Equal(t, []string{"Hello"}, expectations[0].Criteria)
+	assert.Equal(t, []string{"Hello"}, expectations[1].Criteria)
+	assert.Equal(t, []string{"Goodbye"}, expectations[2].Criteria)
+	assert.Contains(t, expectations[3].Error, "timed out")
+	assert.GreaterOrEqual(t, expectations[3].Duration, 0.05)
+}
@@ -0,0 +1,95 @@
+package mimic
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WithTranscript records each interaction with the Mimic (writes, expectations, and Contains checks) along with the
+// output it observed, retrievable via Mimic.Transcript. When used with ForTest, the transcript is logged if the test fails.
+func WithTranscript() Option {
+	return func(opt *mimicOpt) {
+		opt.transcript = true
+	}
+}
+
+// TranscriptEntry is a single interaction recorded in a Transcript
+type TranscriptEntry struct {
+	// Time at which the interaction completed
+	Time time.Time
+	// Operation is the name of the Mimic function invoked, e.g. "ExpectString"
+	Operation string
+	// Arguments are the strings or patterns passed to the operation
+	Arguments []string
+	// Output observed by the operation, stripped of ANSI escape characters: the output read by an expectation, or the
+	// view evaluated by a Contains check
+	Output string
+	// Err is the reason the operation failed, or nil if it succeeded
+	Err error
+}
+
+// Transcript is the sequence of interactions with a Mimic, in the order they completed. See WithTranscript.
+type Transcript []TranscriptEntry
+
+// String formats the transcript for humans, with times relative to the first entry
+func (t Transcript) String() string {
+	var sb strings.Builder
+	for i, entry := range t {
+		fmt.Fprintf(&sb, "%d. +%.3fs %s", i+1, entry.Time.Sub(t[0].Time).Seconds(), FormatCall(entry.Operation, entry.Arguments))
+		if entry.Err != nil {
+			fmt.Fprintf(&sb, " failed: %v", entry.Err)
+		}
+		sb.WriteByte('\n')
+		if output := strings.TrimSpace(entry.Output); output != "" {
+			for _, line := range strings.Split(output, "\n") {
+				sb.WriteString("   | " + strings.TrimRight(line, "\r") + "\n")
+			}
+		}
+	}
+	return sb.String()
+}
+
+// Transcript returns the interactions recorded so far, or nil unless the Mimic was created with WithTranscript
+func (m *Mimic) Transcript() Transcript {
+	return m.transcript.entries()
+}
+
+// notContained describes the criteria missing from a view, or is nil if nothing is missing
+func notContained(failed []string) error {
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("view does not contain %q", failed)
+}
+
+// transcript accumulates entries of a Transcript; a nil transcript records nothing
+type transcript struct {
+	mu      sync.Mutex
+	records Transcript
+}
+
+func (t *transcript) record(operation string, arguments []string, output string, err error) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.records = append(t.records, TranscriptEntry{
+		Time:      time.Now(),
+		Operation: operation,
+		Arguments: append([]string(nil), arguments...),
+		Output:    output,
+		Err:       err,
+	})
+}
+
+func (t *transcript) entries() Transcript {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append(Transcript(nil), t.records...)
+}
@@ -0,0 +1,52 @@
+package mimic
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_Transcript(t *testing.T) {
+	m, err := NewMimic(WithTranscript(), WithIdleTimeout(50*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, _ = m.Tty().WriteString("What is your name? ")
+	assert.NoError(t, m.ExpectString("name?"))
+	_, err = m.WriteString("Tom\r")
+	assert.NoError(t, err)
+	assert.True(t, m.ContainsString("Tom"))
+	assert.False(t, m.ContainsPattern(`age\?`))
+	assert.Error(t, m.ExpectString("age?"))
+
+	entries := m.Transcript()
+	operations := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		operations = append(operations, entry.Operation)
+	}
+	assert.Equal(t, []string{"ExpectString", "WriteString", "ContainsString", "ContainsPattern", "ExpectString"}, operations)
+
+	assert.Equal(t, "What is your name?", entries[0].Output)
+	assert.NoError(t, entries[0].Err)
+	assert.Equal(t, []string{"Tom\r"}, entries[1].Arguments)
+	assert.Equal(t, "What is your name? Tom", entries[2].Output)
+	assert.ErrorContains(t, entries[3].Err, `view does not contain ["age\\?"]`)
+	assert.Error(t, entries[4].Err)
+
+	formatted := entries.String()
+	assert.True(t, strings.HasPrefix(formatted, "1. +0.000s ExpectString(\"name?\")\n   | What is your name?\n2. "), formatted)
+	assert.Contains(t, formatted, `WriteString("Tom\r")`)
+	assert.Contains(t, formatted, `5. `)
+	assert.Contains(t, formatted, `ExpectString("age?") failed: `)
+}
+
+func TestMimic_Transcript_disabled(t *testing.T) {
+	m, err := NewMimic()
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, _ = m.WriteString("hello")
+	assert.Nil(t, m.Transcript())
+}
@@ -1,6 +1,7 @@
 package mimic
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/jimschubert/stripansi"
@@ -21,7 +22,7 @@ func (v *Viewer) String() string {
 		return ""
 	}
 
-	result := v.Mimic.terminal.String()
+	result := withoutSpacers(v.Mimic.terminal.String())
 	if v.Trim {
 		result = strings.TrimSpace(result)
 	}
@@ -32,3 +33,112 @@ func (v *Viewer) String() string {
 
 	return result
 }
+
+// Lines provides the terminal's view as one string per row. Rows are retained even when empty, so an index into Lines
+// is a row number; when Trim is set, trailing whitespace is removed from each row.
+func (v *Viewer) Lines() []string {
+	if v.Mimic == nil {
+		return nil
+	}
+
+	lines := v.rows()
+	for i, line := range lines {
+		lines[i] = v.format(line)
+	}
+	return lines
+}
+
+// Line provides the zero-based row n of the terminal's view, formatted as in Lines.
+// An empty string is returned if n is outside the view.
+func (v *Viewer) Line(n int) string {
+	lines := v.Lines()
+	if n < 0 || n >= len(lines) {
+		return ""
+	}
+	return lines[n]
+}
+
+// LineWidth provides the number of columns zero-based row n of the terminal's view occupies when displayed, formatted as
+// in Lines. This differs from the row's length in runes when it contains wide runes, such as CJK characters, which
+// occupy two columns each.
+func (v *Viewer) LineWidth(n int) int {
+	return textWidth(v.Line(n))
+}
+
+// LastNonEmptyLine provides the bottom-most row of the terminal's view which contains non-whitespace content,
+// formatted as in Lines. This is typically the row an application most recently wrote to, such as a prompt.
+func (v *Viewer) LastNonEmptyLine() string {
+	lines := v.Lines()
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.TrimSpace(lines[i]) != "" {
+			return lines[i]
+		}
+	}
+	return ""
+}
+
+// Region provides the rectangle of the terminal's view starting at the zero-based row and column, spanning height rows
+// and width columns. Rows of the region are separated by newlines and formatted as in Lines. The rectangle is clipped
+// to the bounds of the view.
+func (v *Viewer) Region(row, column, height, width int) string {
+	if v.Mimic == nil || row < 0 || column < 0 || height < 1 || width < 1 {
+		return ""
+	}
+
+	lines := v.rows()
+	region := make([]string, 0, height)
+	for y := row; y < row+height && y < len(lines); y++ {
+		cells := []rune(lines[y])
+		start, end := column, column+width
+		if start > len(cells) {
+			start = len(cells)
+		}
+		if end > len(cells) {
+			end = len(cells)
+		}
+		region = append(region, v.format(string(cells[start:end])))
+	}
+	return strings.Join(region, "\n")
+}
+
+// FormatScreen formats rows of a view, such as those provided by Viewer.Lines, as a numbered screen for humans, e.g.
+// "  0 | $ ls". Each row is numbered by its index in rows, and trailing empty rows are omitted. When maxRows is
+// positive, only the last maxRows rows are included. A screen without content is formatted as "    | (empty)".
+func FormatScreen(rows []string, maxRows int) string {
+	last := len(rows)
+	for last > 0 && strings.TrimRight(rows[last-1], " \t") == "" {
+		last--
+	}
+	if last == 0 {
+		return "    | (empty)"
+	}
+
+	first := 0
+	if maxRows > 0 && last > maxRows {
+		first = last - maxRows
+	}
+
+	var b strings.Builder
+	for i := first; i < last; i++ {
+		_, _ = fmt.Fprintf(&b, "%3d | %s\n", i, strings.TrimRight(rows[i], " \t"))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// rows provides the unformatted rows of the terminal's view, one rune per column. The column covered by the right half
+// of a wide rune holds wideSpacer, which format removes.
+func (v *Viewer) rows() []string {
+	return strings.Split(strings.TrimSuffix(v.Mimic.terminal.String(), "\n"), "\n")
+}
+
+// format applies the Viewer's formatting options to a single row
+func (v *Viewer) format(line string) string {
+	line = withoutSpacers(line)
+	if v.Trim {
+		line = strings.TrimRight(line, " \t")
+	}
+	if v.StripAnsi {
+		line = stripansi.String(line)
+	}
+	return line
+}
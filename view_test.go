@@ -0,0 +1,75 @@
+package mimic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewer_Lines(t *testing.T) {
+	m, err := NewMimic(WithSize(4, 12))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, _ = m.Tty().WriteString("\x1b[32mfirst\x1b[0m\r\n\r\nName: ")
+	assert.NoError(t, m.Flush())
+
+	trimmed := Viewer{Mimic: m, StripAnsi: true, Trim: true}
+	assert.Equal(t, []string{"first", "", "Name:", ""}, trimmed.Lines())
+	assert.Equal(t, "first", trimmed.Line(0))
+	assert.Equal(t, "", trimmed.Line(1))
+	assert.Equal(t, "", trimmed.Line(4), "out of range rows should be empty")
+	assert.Equal(t, "Name:", trimmed.LastNonEmptyLine())
+
+	untrimmed := Viewer{Mimic: m}
+	assert.Equal(t, "Name:       ", untrimmed.Line(2))
+	assert.Equal(t, "Name:       ", untrimmed.LastNonEmptyLine())
+
+	assert.Nil(t, (&Viewer{}).Lines())
+}
+
+func TestViewer_Region(t *testing.T) {
+	m, err := NewMimic(WithSize(4, 20))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, _ = m.Tty().WriteString("Files     | Preview\r\nmain.go   | package\r\nREADME.md | # mimic\r\n\x1b[7m NORMAL \x1b[0m 3 files")
+	assert.NoError(t, m.Flush())
+
+	v := Viewer{Mimic: m, StripAnsi: true, Trim: true}
+	tests := []struct {
+		name                       string
+		row, column, height, width int
+		want                       string
+	}{
+		{name: "left pane", row: 1, column: 0, height: 2, width: 9, want: "main.go\nREADME.md"},
+		{name: "right pane", row: 1, column: 12, height: 2, width: 8, want: "package\n# mimic"},
+		{name: "status bar", row: 3, column: 0, height: 1, width: 20, want: " NORMAL  3 files"},
+		{name: "clipped", row: 2, column: 12, height: 5, width: 50, want: "# mimic\niles"},
+		{name: "empty", row: 0, column: 0, height: 0, width: 5, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, v.Region(tt.row, tt.column, tt.height, tt.width))
+		})
+	}
+}
+
+func TestFormatScreen(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    []string
+		maxRows int
+		want    string
+	}{
+		{name: "numbers rows", rows: []string{"$ ls", "", "a.txt", "", ""}, want: "  0 | $ ls\n  1 | \n  2 | a.txt"},
+		{name: "keeps the last rows", rows: []string{"a", "b", "c", ""}, maxRows: 2, want: "  1 | b\n  2 | c"},
+		{name: "empty", rows: []string{"", "  "}, want: "    | (empty)"},
+		{name: "nil", want: "    | (empty)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormatScreen(tt.rows, tt.maxRows))
+		})
+	}
+}
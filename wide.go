@@ -0,0 +1,100 @@
+package mimic
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jimschubert/mimic/internal/vt10x"
+)
+
+// wideSpacer fills the column covered by the right half of a wide rune, as the emulated terminal otherwise gives every
+// rune a single column. It's a Unicode noncharacter, so it doesn't occur in output.
+const wideSpacer = '\uFDD0'
+
+// mirrors vt10x's unexported cursor state flag, set once a rune is written to the last column (see TestVT10XFlags)
+const cursorWrapNext = 1 << 1
+
+// withoutSpacers removes the columns covered by wide runes from text of the view
+func withoutSpacers(s string) string {
+	return strings.ReplaceAll(s, string(wideSpacer), "")
+}
+
+// textWidth is the number of columns s occupies when displayed
+func textWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// wideRunes gives East Asian wide and fullwidth runes two columns of the emulated terminal, by following each with
+// wideSpacer. As terminals do, a wide rune which doesn't fit on the current row is wrapped to the next rather than
+// split. It wraps the view writer, and requires whole runes per write, as written by the console.
+type wideRunes struct {
+	terminal vt10x.Terminal
+	w        io.Writer
+	parser   controlParser
+}
+
+func (r *wideRunes) Write(p []byte) (int, error) {
+	start := 0
+	for i := 0; i < len(p); {
+		c, size := utf8.DecodeRune(p[i:])
+		if r.parser.state != controlGround || size == 1 || runeWidth(c) != 2 {
+			for _, b := range p[i : i+size] {
+				r.parser.feed(b)
+			}
+			i += size
+			continue
+		}
+		if err := r.write(p[start:i]); err != nil {
+			return start, err
+		}
+		if err := r.writeWide(p[i : i+size]); err != nil {
+			return i, err
+		}
+		i += size
+		start = i
+	}
+	if err := r.write(p[start:]); err != nil {
+		return start, err
+	}
+	return len(p), nil
+}
+
+func (r *wideRunes) write(p []byte) error {
+	if len(p) == 0 {
+		return nil
+	}
+	_, err := r.w.Write(p)
+	return err
+}
+
+// writeWide writes the wide rune encoded by p, followed by wideSpacer
+func (r *wideRunes) writeWide(p []byte) error {
+	// the terminal locks itself to write, so it's locked only while inspected
+	r.terminal.Lock()
+	columns, _ := r.terminal.Size()
+	cursor := r.terminal.Cursor()
+	wraps := r.terminal.Mode()&vt10x.ModeWrap != 0
+	r.terminal.Unlock()
+	if columns > 1 && cursor.X == columns-1 && cursor.State&cursorWrapNext == 0 && wraps {
+		// leave the last column blank, wrapping the rune to the next row
+		if err := r.write([]byte{' '}); err != nil {
+			return err
+		}
+	}
+	if err := r.write(p); err != nil {
+		return err
+	}
+	r.terminal.Lock()
+	cursor = r.terminal.Cursor()
+	r.terminal.Unlock()
+	if cursor.State&cursorWrapNext != 0 {
+		// the rune occupies the last column, so there's no room for its right half
+		return nil
+	}
+	return r.write([]byte(string(wideSpacer)))
+}
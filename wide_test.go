@@ -0,0 +1,68 @@
+package mimic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jimschubert/mimic/internal/vt10x"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMimic_wideRunes(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithSize(3, 12), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Device().Write([]byte("\x1b]0;窗口\a名前: \x1b[32m世界\x1b[0m!"))
+	assert.NoError(t, err)
+
+	assert.True(t, m.ContainsString("名前: 世界!"))
+	row, column := m.Cursor()
+	assert.Equal(t, 0, row)
+	assert.Equal(t, 11, column, "wide runes should occupy two columns each")
+
+	v := Viewer{Mimic: m, StripAnsi: true, Trim: true}
+	assert.Equal(t, "名前: 世界!", v.Line(0))
+	assert.Equal(t, 11, v.LineWidth(0))
+	assert.Equal(t, "世界", v.Region(0, 6, 1, 4))
+
+	cell, err := m.CellAt(0, 6)
+	assert.NoError(t, err)
+	assert.Equal(t, Cell{Rune: '世', Width: 2, Foreground: Green, Background: DefaultColor}, cell)
+	cell, err = m.CellAt(0, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cell.Width, "the right half of a wide rune should have no width")
+	assert.True(t, m.ContainsStyledString("世界", WithForeground(Green)))
+}
+
+func TestMimic_wideRunesWrap(t *testing.T) {
+	m, err := NewMimic(WithInMemoryPty(), WithSize(3, 5), WithIdleTimeout(100*time.Millisecond))
+	assert.NoError(t, err)
+	defer m.Close()
+
+	_, err = m.Device().Write([]byte("ab世界c"))
+	assert.NoError(t, err)
+	assert.NoError(t, m.Flush())
+
+	v := Viewer{Mimic: m, StripAnsi: true, Trim: true}
+	assert.Equal(t, []string{"ab世", "界c", ""}, v.Lines(), "a wide rune which doesn't fit should wrap whole")
+	row, column := m.Cursor()
+	assert.Equal(t, 1, row)
+	assert.Equal(t, 3, column)
+}
+
+// cursorWrapNext and glyphWrap mirror flags which vt10x doesn't export, so an upgrade of vt10x which changes them must
+// fail here rather than silently break wrapping
+func TestVT10XFlags(t *testing.T) {
+	terminal := vt10x.New(vt10x.WithSize(3, 2))
+
+	_, _ = terminal.Write([]byte("ab"))
+	assert.Zero(t, terminal.Cursor().State&cursorWrapNext)
+	_, _ = terminal.Write([]byte("c"))
+	assert.NotZero(t, terminal.Cursor().State&cursorWrapNext, "a rune written to the last column should await wrapping")
+	assert.Zero(t, terminal.Cell(2, 0).Mode&glyphWrap)
+
+	_, _ = terminal.Write([]byte("d"))
+	assert.Zero(t, terminal.Cursor().State&cursorWrapNext)
+	assert.NotZero(t, terminal.Cell(2, 0).Mode&glyphWrap, "the last cell of a wrapped row should be marked")
+}